@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// outputAsciiDoc writes entries as an AsciiDoc document with a section per
+// version.
+func outputAsciiDoc(displayName string, entries []ChangelogEntry) {
+	fmt.Printf("= %s changelog\n", displayName)
+
+	for _, entry := range entries {
+		if !entry.ReleasedAt.IsZero() {
+			fmt.Printf("\n== %s (%s)\n", entry.Version, entry.ReleasedAt.Format("2006-01-02"))
+		} else {
+			fmt.Printf("\n== %s\n", entry.Version)
+		}
+
+		for _, section := range entry.Sections {
+			fmt.Printf("\n=== %s\n\n", section.Name)
+			for _, change := range section.Changes {
+				fmt.Printf("* %s\n", asciiDocInline(change))
+			}
+		}
+
+		if len(entry.Changes) > 0 {
+			fmt.Println()
+		}
+		for _, change := range entry.Changes {
+			fmt.Printf("* %s\n", asciiDocInline(change))
+		}
+	}
+}
+
+// asciiDocInline rewrites markdown links as AsciiDoc url[text] macros and
+// nested "  - " bullets as deeper "**" items. Bold and code spans already
+// mean the same thing in both.
+func asciiDocInline(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines[1:] {
+		text := strings.TrimLeft(line, " ")
+		level := (len(line)-len(text))/2 + 1
+		lines[i+1] = strings.Repeat("*", level) + " " + strings.TrimPrefix(text, "- ")
+	}
+	s = strings.Join(lines, "\n")
+	s = mdLinkRegex.ReplaceAllStringFunc(s, func(link string) string {
+		m := mdLinkRegex.FindStringSubmatch(link)
+		return fmt.Sprintf("%s[%s]", m[2], strings.ReplaceAll(m[1], "]", `\]`))
+	})
+	return s
+}
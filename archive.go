@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// runArchiveCommand writes the full history of every source to outDir as
+// <name>.md and <name>.json, for an offline, diffable mirror of upstream
+// changelogs.
+func runArchiveCommand(outDir string) {
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	named := make(map[string]Source, len(sources))
+	for name, src := range sources {
+		named[name] = src.withHistory()
+	}
+	var written int
+	for _, r := range fetchSources(named) {
+		if r.err != nil {
+			continue
+		}
+		if len(r.entries) == 0 {
+			continue
+		}
+		for i := range r.entries {
+			r.entries[i].Source = r.source.DisplayName
+		}
+
+		err := writeSiteFile(filepath.Join(outDir, r.name+".md"), func(f *os.File) error {
+			fmt.Fprintf(f, "# %s\n\n", r.source.DisplayName)
+			for i := range r.entries {
+				if i > 0 {
+					fmt.Fprintln(f)
+				}
+				writeMarkdown(f, &r.entries[i])
+			}
+			return nil
+		})
+		if err == nil {
+			err = writeSiteFile(filepath.Join(outDir, r.name+".json"), func(f *os.File) error {
+				return encodeJSON(f, r.entries)
+			})
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		written++
+	}
+
+	fmt.Printf("Archived %d sources to %s\n", written, outDir)
+}
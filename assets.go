@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// Asset is a file attached to a GitHub release.
+type Asset struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+	URL  string `json:"url"`
+}
+
+// runAssetsCommand lists the release assets of a version (the latest stable
+// one by default), or downloads the ones whose names match the glob pattern
+// into outDir, verifying them with verify.
+func runAssetsCommand(source Source, targetVersion, pattern, outDir string, verify bool, minisignKey string) {
+	entries, err := source.Fetch()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching changelog: %v\n", err)
+		os.Exit(1)
+	}
+	entry := assetsEntry(source, entries, targetVersion)
+
+	if pattern == "" {
+		for _, asset := range entry.Assets {
+			fmt.Printf("%-50s %10s  %s\n", asset.Name, formatSize(asset.Size), asset.URL)
+		}
+		return
+	}
+
+	var matched int
+	var failed bool
+	for _, asset := range entry.Assets {
+		if ok, err := path.Match(pattern, asset.Name); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Invalid -download pattern: %v\n", err)
+			os.Exit(1)
+		} else if !ok {
+			continue
+		}
+		matched++
+		dest := filepath.Join(outDir, asset.Name)
+		if err := downloadFile(asset.URL, dest); err != nil {
+			fmt.Fprintf(os.Stderr, "Error downloading %s: %v\n", asset.Name, err)
+			os.Exit(1)
+		}
+		fmt.Printf("Downloaded %s (%s)\n", dest, formatSize(asset.Size))
+		if verify && !verifyAsset(entry, asset, dest, minisignKey) {
+			failed = true
+		}
+	}
+	if matched == 0 {
+		fmt.Fprintf(os.Stderr, "Error: No assets of %s %s match %s\n", source.DisplayName, entry.Version, pattern)
+		os.Exit(1)
+	}
+	if failed {
+		fmt.Fprintf(os.Stderr, "Error: Verification failed\n")
+		os.Exit(1)
+	}
+}
+
+// assetsEntry picks the entry with targetVersion, or the latest stable one,
+// exiting if it isn't found or has no assets.
+func assetsEntry(source Source, entries []ChangelogEntry, targetVersion string) *ChangelogEntry {
+	if targetVersion == "" {
+		if stable := filterChannel(entries, false); len(stable) > 0 {
+			entries = stable
+		}
+	}
+	if len(entries) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: No changelog entries found\n")
+		os.Exit(1)
+	}
+	i := 0
+	if targetVersion != "" {
+		if i = versionIndex(entries, targetVersion); i < 0 {
+			fmt.Fprintf(os.Stderr, "Error: Version %s not found\n", targetVersion)
+			os.Exit(1)
+		}
+	}
+	if len(entries[i].Assets) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: %s %s has no release assets\n", source.DisplayName, entries[i].Version)
+		os.Exit(1)
+	}
+	return &entries[i]
+}
+
+func downloadFile(url, dest string) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", "aic-changelog")
+	// Large assets can take longer than -timeout allows for a whole request,
+	// so downloads are only bounded by the transport's connect and header
+	// timeouts.
+	client := *httpClient
+	client.Timeout = 0
+	resp, err := doWithRetry(&client, req)
+	if err != nil {
+		return fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return &httpStatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		os.Remove(dest)
+		return err
+	}
+	return f.Close()
+}
+
+// formatSize formats a byte count as e.g. "12.3 MB".
+func formatSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
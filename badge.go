@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"regexp"
+	"unicode/utf8"
+)
+
+const badgeColor = "#007ec6"
+
+var numericVersionRegex = regexp.MustCompile(`^\d+\.\d+`)
+
+const badgeSVG = `<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[2]s: %[3]s">
+<title>%[2]s: %[3]s</title>
+<linearGradient id="s" x2="0" y2="100%%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+<clipPath id="r"><rect width="%[1]d" height="20" rx="3" fill="#fff"/></clipPath>
+<g clip-path="url(#r)"><rect width="%[4]d" height="20" fill="#555"/><rect x="%[4]d" width="%[5]d" height="20" fill="%[6]s"/><rect width="%[1]d" height="20" fill="url(#s)"/></g>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+<text x="%[7]d" y="15" fill="#010101" fill-opacity=".3">%[2]s</text><text x="%[7]d" y="14">%[2]s</text>
+<text x="%[8]d" y="15" fill="#010101" fill-opacity=".3">%[3]s</text><text x="%[8]d" y="14">%[3]s</text>
+</g>
+</svg>
+`
+
+// shieldsEndpoint is the JSON schema read by shields.io's endpoint badge, so
+// the output can be hosted anywhere and rendered through shields.io.
+type shieldsEndpoint struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// runBadgeCommand writes a "<tool> vX.Y.Z" badge for the latest version of
+// source, as a flat SVG or as shields.io endpoint JSON.
+func runBadgeCommand(source Source, outPath, color string, jsonOutput bool) {
+	entries, err := source.Fetch()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching changelog: %v\n", err)
+		os.Exit(1)
+	}
+	if len(entries) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: No changelog entries found\n")
+		os.Exit(1)
+	}
+
+	// Prereleases only count when the source has nothing else, as in the
+	// main view
+	if stable := filterChannel(entries, false); len(stable) > 0 {
+		entries = stable
+	}
+	message := entries[0].Version
+	if numericVersionRegex.MatchString(message) {
+		message = "v" + message
+	}
+
+	var out []byte
+	if jsonOutput {
+		out, err = json.MarshalIndent(shieldsEndpoint{SchemaVersion: 1, Label: source.DisplayName, Message: message, Color: color}, "", "  ")
+		out = append(out, '\n')
+	} else {
+		out = []byte(badgeSVGFor(source.DisplayName, message, color))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if outPath == "" {
+		os.Stdout.Write(out)
+		return
+	}
+	if err := os.WriteFile(outPath, out, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// badgeSVGFor renders a shields.io-style flat badge. Text widths are
+// estimated, since measuring them properly would need the font.
+func badgeSVGFor(label, message, color string) string {
+	labelWidth := utf8.RuneCountInString(label)*7 + 10
+	messageWidth := utf8.RuneCountInString(message)*7 + 10
+	return fmt.Sprintf(badgeSVG,
+		labelWidth+messageWidth,
+		html.EscapeString(label),
+		html.EscapeString(message),
+		labelWidth,
+		messageWidth,
+		html.EscapeString(color),
+		labelWidth/2,
+		labelWidth+messageWidth/2,
+	)
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheTTL is how long fetched responses are reused; cache_ttl in
+// sources.yaml overrides it.
+var cacheTTL = 10 * time.Minute
+
+// cacheMaxAge is how long a cached response is kept after it was last
+// fetched or revalidated, as a fallback for when a rate limit runs out.
+const cacheMaxAge = 30 * 24 * time.Hour
+
+// cacheKeyIgnoredHeaders are left out of cache keys: credentials don't change
+// the response, and keying on them would orphan a file per token.
+var cacheKeyIgnoredHeaders = map[string]bool{
+	"authorization": true,
+	"private-token": true,
+}
+
+// cacheDisabled bypasses the cache entirely (-no-cache), and cacheRefresh
+// ignores cacheTTL so every response is revalidated (-refresh).
+var cacheDisabled, cacheRefresh bool
+
+type cachedResponse struct {
+	URL          string    `json:"url"`
+	FetchedAt    time.Time `json:"fetched_at"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	Body         []byte    `json:"body"`
+}
+
+func cacheDir() (string, error) {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "aic"), nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "aic"), nil
+}
+
+// cachePath returns the cache file for a request. Headers other than
+// credentials are part of the key, since they can change the response
+// (e.g. Accept).
+func cachePath(url string, headers map[string]string) (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		if !cacheKeyIgnoredHeaders[strings.ToLower(k)] {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	h.Write([]byte(url))
+	for _, k := range keys {
+		h.Write([]byte("\n" + k + ": " + headers[k]))
+	}
+	return filepath.Join(dir, hex.EncodeToString(h.Sum(nil))+".json"), nil
+}
+
+// readCache returns the cached response for a request, if any, regardless
+// of its age.
+func readCache(url string, headers map[string]string) (*cachedResponse, bool) {
+	if cacheDisabled {
+		return nil, false
+	}
+	path, err := cachePath(url, headers)
+	if err != nil {
+		return nil, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var cached cachedResponse
+	if json.Unmarshal(data, &cached) != nil {
+		return nil, false
+	}
+	return &cached, true
+}
+
+func (c *cachedResponse) fresh() bool {
+	return !cacheRefresh && time.Since(c.FetchedAt) < cacheTTL
+}
+
+// writeCache stores a response. Failing to cache isn't worth failing the
+// command over, so errors are ignored.
+func writeCache(url string, headers map[string]string, cached *cachedResponse) {
+	if cacheDisabled {
+		return
+	}
+	path, err := cachePath(url, headers)
+	if err != nil {
+		return
+	}
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return
+	}
+	if os.MkdirAll(filepath.Dir(path), 0755) != nil {
+		return
+	}
+	// Write then rename, so concurrent fetches never read a partial file
+	tmp, err := os.CreateTemp(filepath.Dir(path), "*.tmp")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err != nil || closeErr != nil || os.Rename(tmp.Name(), path) != nil {
+		os.Remove(tmp.Name())
+	}
+	pruneOnce.Do(func() { pruneCache(filepath.Dir(path)) })
+}
+
+var pruneOnce sync.Once
+
+// pruneCache removes cached responses older than cacheMaxAge, along with
+// temp files left by interrupted writes. It runs at most once a day, going
+// by the mtime of a marker file.
+func pruneCache(dir string) {
+	marker := filepath.Join(dir, ".pruned")
+	if info, err := os.Stat(marker); err == nil && time.Since(info.ModTime()) < 24*time.Hour {
+		return
+	}
+	if f, err := os.Create(marker); err == nil {
+		f.Close()
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		ext := filepath.Ext(file.Name())
+		if ext != ".json" && ext != ".tmp" {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+		maxAge := cacheMaxAge
+		if ext == ".tmp" {
+			maxAge = time.Hour
+		}
+		if time.Since(info.ModTime()) > maxAge {
+			os.Remove(filepath.Join(dir, file.Name()))
+		}
+	}
+}
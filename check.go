@@ -0,0 +1,110 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Exit codes of aic check. 1 is left for errors, like everywhere else.
+const (
+	checkUpToDate   = 0
+	checkNewRelease = 2
+)
+
+// runCheckCommand exits with checkNewRelease if the source has released
+// anything newer than the version recorded for it in stateFile (or
+// sinceVersion), printing the new versions, and with checkUpToDate
+// otherwise. The state file is updated to the latest version; on the first
+// run it only records it.
+func runCheckCommand(name string, source Source, stateFile, sinceVersion string) {
+	state := map[string]string{}
+	if stateFile != "" {
+		var err error
+		if state, err = readCheckState(stateFile); err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading %s: %v\n", stateFile, err)
+			os.Exit(1)
+		}
+		if sinceVersion == "" {
+			sinceVersion = state[name]
+		}
+	}
+
+	entries, err := source.Fetch()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching changelog: %v\n", err)
+		os.Exit(1)
+	}
+	if stable := filterChannel(entries, false); len(stable) > 0 {
+		entries = stable
+	}
+	if len(entries) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: No changelog entries found\n")
+		os.Exit(1)
+	}
+
+	var newer []ChangelogEntry
+	switch i := versionIndex(entries, sinceVersion); {
+	case sinceVersion == "":
+		fmt.Fprintf(os.Stderr, "Recorded %s %s\n", source.DisplayName, entries[0].Version)
+	case i >= 0:
+		newer = entries[:i]
+	default:
+		// An unknown version that can't be compared still means something
+		// else is out now
+		if newer, err = newerThan(entries, sinceVersion); err != nil {
+			newer = entries[:1]
+		}
+	}
+
+	if stateFile != "" {
+		state[name] = entries[0].Version
+		if err := writeCheckState(stateFile, state); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", stateFile, err)
+			os.Exit(1)
+		}
+	}
+
+	if len(newer) == 0 {
+		os.Exit(checkUpToDate)
+	}
+	for _, e := range newer {
+		fmt.Println(e.Version)
+	}
+	os.Exit(checkNewRelease)
+}
+
+// readCheckState reads a state file of "<source> <version>" lines. A
+// missing file is an empty state.
+func readCheckState(path string) (map[string]string, error) {
+	state := map[string]string{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if name, ver, ok := strings.Cut(strings.TrimSpace(line), " "); ok {
+			state[name] = strings.TrimSpace(ver)
+		}
+	}
+	return state, nil
+}
+
+func writeCheckState(path string, state map[string]string) error {
+	names := make([]string, 0, len(state))
+	for name := range state {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s %s\n", name, state[name])
+	}
+	return os.WriteFile(path, []byte(b.String()), 0644)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type comparedSource struct {
+	Source     string    `json:"source"`
+	Name       string    `json:"name"`
+	Version    string    `json:"version"`
+	ReleasedAt time.Time `json:"released_at,omitzero"`
+	Changes    int       `json:"changes"`
+}
+
+// runCompareCommand prints the latest stable release of every source side
+// by side, newest first.
+func runCompareCommand(jsonOutput bool) {
+	var compared []comparedSource
+	for _, r := range fetchAllSources() {
+		if r.err != nil {
+			continue
+		}
+		entries := r.entries
+		if stable := filterChannel(entries, false); len(stable) > 0 {
+			entries = stable
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		latest := entries[0]
+		compared = append(compared, comparedSource{
+			Source:     r.name,
+			Name:       r.source.DisplayName,
+			Version:    latest.Version,
+			ReleasedAt: latest.ReleasedAt,
+			Changes:    latest.changeCount(),
+		})
+	}
+
+	// Undated sources go last
+	sort.SliceStable(compared, func(i, j int) bool {
+		return compared[i].ReleasedAt.After(compared[j].ReleasedAt)
+	})
+
+	if jsonOutput {
+		if compared == nil {
+			compared = []comparedSource{}
+		}
+		writeJSON(compared)
+		return
+	}
+
+	const (
+		colTool    = 30
+		colVersion = 20
+		colDate    = 10
+		colChanges = 7
+	)
+	border := func(left, mid, right string) {
+		fmt.Printf("%s%s%s%s%s%s%s%s%s\n", left,
+			strings.Repeat("─", colTool+2), mid,
+			strings.Repeat("─", colVersion+2), mid,
+			strings.Repeat("─", colDate+2), mid,
+			strings.Repeat("─", colChanges+2), right)
+	}
+
+	border("┌", "┬", "┐")
+	fmt.Printf("│ %-*s │ %-*s │ %-*s │ %-*s │\n",
+		colTool, "Tool", colVersion, "Latest", colDate, "Released", colChanges, "Changes")
+	border("├", "┼", "┤")
+	for _, c := range compared {
+		date := "-"
+		if !c.ReleasedAt.IsZero() {
+			date = c.ReleasedAt.Format("2006-01-02")
+		}
+		fmt.Printf("│ %-*s │ %-*s │ %-*s │ %*d │\n",
+			colTool, truncateString(c.Name, colTool),
+			colVersion, truncateString(c.Version, colVersion),
+			colDate, date,
+			colChanges, c.Changes)
+	}
+	border("└", "┴", "┘")
+}
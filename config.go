@@ -0,0 +1,150 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// customSource is a user-defined source in sources.yaml:
+//
+//	sources:
+//	  - name: mytool
+//	    display_name: My Tool
+//	    type: raw-markdown
+//	    url: https://raw.githubusercontent.com/me/mytool/main/CHANGELOG.md
+//	    version_pattern: '^v?(\d+\.\d+\.\d+)'
+type customSource struct {
+	Name           string `yaml:"name"`
+	DisplayName    string `yaml:"display_name"`
+	Type           string `yaml:"type"`
+	URL            string `yaml:"url"`
+	VersionPattern string `yaml:"version_pattern"`
+	Command        string `yaml:"command"`
+}
+
+func configDir() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "aic"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "aic"), nil
+}
+
+// loadCustomSources adds the sources declared in sources.yaml to the
+// sources map. A custom source with the same name as a built-in replaces it.
+// It also reads the watched keywords, cache TTL and retries. A bad setting or
+// source is skipped and reported, without affecting the rest of the file.
+func loadCustomSources() []error {
+	dir, err := configDir()
+	if err != nil {
+		return []error{err}
+	}
+	path := filepath.Join(dir, "sources.yaml")
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return []error{err}
+	}
+
+	var config struct {
+		Sources  []customSource `yaml:"sources"`
+		Watch    []string       `yaml:"watch"`
+		CacheTTL string         `yaml:"cache_ttl"`
+		Retries  *int           `yaml:"retries"`
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return []error{fmt.Errorf("%s: %w", path, err)}
+	}
+
+	var errs []error
+	for _, cs := range config.Sources {
+		src, err := cs.source()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: source '%s': %w", path, cs.Name, err))
+			continue
+		}
+		sources[cs.Name] = src
+	}
+	if config.CacheTTL != "" {
+		if ttl, err := parseAge(config.CacheTTL); err != nil {
+			errs = append(errs, fmt.Errorf("%s: cache_ttl: %w", path, err))
+		} else {
+			cacheTTL = ttl
+		}
+	}
+	if config.Retries != nil {
+		if *config.Retries < 0 {
+			errs = append(errs, fmt.Errorf("%s: retries must not be negative", path))
+		} else {
+			maxRetries = *config.Retries
+		}
+	}
+	for _, keyword := range config.Watch {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			watchKeywords = append(watchKeywords, keyword)
+		}
+	}
+	return errs
+}
+
+func (cs customSource) source() (Source, error) {
+	if cs.Name == "" {
+		return Source{}, fmt.Errorf("missing name")
+	}
+	if cs.URL == "" {
+		return Source{}, fmt.Errorf("missing url")
+	}
+	src := Source{DisplayName: cs.DisplayName, Command: cs.Command, Custom: true}
+	if src.DisplayName == "" {
+		src.DisplayName = cs.Name
+	}
+
+	switch cs.Type {
+	case "github-releases", "":
+		spec := strings.TrimPrefix(cs.URL, "https://github.com/")
+		gh, err := githubSource(strings.TrimSuffix(spec, "/"))
+		if err != nil {
+			return Source{}, err
+		}
+		src.Owner, src.Repo = gh.Owner, gh.Repo
+	case "raw-markdown":
+		url, pattern := cs.URL, cs.VersionPattern
+		if pattern == "" {
+			pattern = defaultVersionPattern
+		}
+		src.ChangelogURL = url
+		src.FetchFunc = func() ([]ChangelogEntry, error) {
+			return fetchMarkdownChangelog(url, pattern)
+		}
+	case "html":
+		src.ChangelogURL = cs.URL
+		src.VersionPattern = cs.VersionPattern
+	default:
+		newSource := sourceTypes[cs.Type]
+		if newSource == nil {
+			return Source{}, fmt.Errorf("unknown type '%s'", cs.Type)
+		}
+		typed, err := newSource(cs.URL)
+		if err != nil {
+			return Source{}, err
+		}
+		typed.Custom = true
+		typed.Command = cs.Command
+		if cs.DisplayName != "" {
+			typed.DisplayName = cs.DisplayName
+		}
+		return typed, nil
+	}
+	return src, nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+)
+
+// outputCSV writes one row per change with source, version, date and change
+// columns. With tsv set, fields are tab-separated instead.
+func outputCSV(displayName string, entries []ChangelogEntry, tsv bool) {
+	w := csv.NewWriter(os.Stdout)
+	if tsv {
+		w.Comma = '\t'
+	}
+
+	w.Write([]string{"source", "version", "date", "change"})
+	for _, entry := range entries {
+		date := ""
+		if !entry.ReleasedAt.IsZero() {
+			date = entry.ReleasedAt.Format("2006-01-02")
+		}
+		for _, section := range entry.Sections {
+			for _, change := range section.Changes {
+				w.Write([]string{displayName, entry.Version, date, change})
+			}
+		}
+		for _, change := range entry.Changes {
+			w.Write([]string{displayName, entry.Version, date, change})
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing CSV: %v\n", err)
+		os.Exit(1)
+	}
+}
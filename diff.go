@@ -0,0 +1,75 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+var breakingRegex = regexp.MustCompile(`(?i)^\W*breaking\b`)
+
+// diffCategories are the sections of a consolidated diff, in display order.
+// Breaking changes come first so they can't be missed.
+var diffCategories = []struct {
+	category, name string
+}{
+	{"breaking", "Breaking Changes"},
+	{"security", "Security"},
+	{"added", "Added"},
+	{"changed", "Changed"},
+	{"deprecated", "Deprecated"},
+	{"removed", "Removed"},
+	{"fixed", "Fixed"},
+}
+
+// consolidateEntries combines entries (newest first) into a single entry
+// listing all of their changes, grouped by changeCategory.
+// Changes repeated across entries are listed once.
+func consolidateEntries(version, url string, entries []ChangelogEntry) ChangelogEntry {
+	byCategory := make(map[string][]string)
+	seen := make(map[string]bool)
+	add := func(sectionCategory, change string) {
+		if key := strings.TrimSpace(change); !seen[key] {
+			seen[key] = true
+			category := changeCategory(sectionCategory, change)
+			byCategory[category] = append(byCategory[category], change)
+		}
+	}
+
+	consolidated := ChangelogEntry{Version: version, URL: url}
+	refs := make(map[string]bool)
+	for _, e := range entries {
+		for _, section := range e.Sections {
+			for _, change := range section.Changes {
+				add(section.Category, change)
+			}
+		}
+		for _, change := range e.Changes {
+			add("", change)
+		}
+		for _, ref := range e.Refs {
+			if !refs[ref.URL] {
+				refs[ref.URL] = true
+				consolidated.Refs = append(consolidated.Refs, ref)
+			}
+		}
+	}
+	if len(entries) > 0 {
+		consolidated.ReleasedAt = entries[0].ReleasedAt
+	}
+
+	for _, c := range diffCategories {
+		if changes := byCategory[c.category]; len(changes) > 0 {
+			consolidated.Sections = append(consolidated.Sections, Section{Name: c.name, Category: c.category, Changes: changes})
+		}
+	}
+	return consolidated
+}
+
+// changeCategory is the diffCategories category of a change: breaking if
+// listed as such, otherwise its Keep a Changelog type.
+func changeCategory(sectionCategory, change string) string {
+	if sectionCategory == "breaking" || breakingRegex.MatchString(change) {
+		return "breaking"
+	}
+	return strings.ToLower(kaclCategory(sectionCategory, change))
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate,omitempty"`
+	Description string  `xml:"description"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// outputRSS writes entries as an RSS 2.0 feed. Item GUIDs are derived from
+// the source name and version so they stay stable across runs.
+func outputRSS(sourceName string, source Source, entries []ChangelogEntry) {
+	channel := rssChannel{
+		Title:       source.DisplayName + " changelog",
+		Link:        source.URL(),
+		Description: fmt.Sprintf("Releases of %s, generated by aic", source.DisplayName),
+	}
+	if len(entries) > 0 && !entries[0].ReleasedAt.IsZero() {
+		channel.LastBuildDate = entries[0].ReleasedAt.Format(time.RFC1123Z)
+	}
+
+	for _, entry := range entries {
+		item := rssItem{
+			Title:       fmt.Sprintf("%s %s", source.DisplayName, entry.Version),
+			Link:        entryURL(source, &entry),
+			GUID:        rssGUID{Value: fmt.Sprintf("aic:%s:%s", sourceName, entry.Version)},
+			Description: entryHTML(&entry),
+		}
+		if !entry.ReleasedAt.IsZero() {
+			item.PubDate = entry.ReleasedAt.Format(time.RFC1123Z)
+		}
+		channel.Items = append(channel.Items, item)
+	}
+
+	writeXML(rssFeed{Version: "2.0", Channel: channel})
+}
+
+func writeXML(v any) {
+	if err := encodeXML(os.Stdout, v); err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding XML: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func encodeXML(w io.Writer, v any) error {
+	fmt.Fprint(w, xml.Header)
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+// entryURL is the entry's own permalink, falling back to the source's
+// changelog for entries that weren't fetched through Source.Fetch.
+func entryURL(source Source, entry *ChangelogEntry) string {
+	if entry.URL != "" {
+		return entry.URL
+	}
+	return source.URL()
+}
+
+// entryHTML renders an entry's changes as an HTML fragment for feed bodies.
+func entryHTML(entry *ChangelogEntry) string {
+	var b strings.Builder
+	for _, section := range entry.Sections {
+		fmt.Fprintf(&b, "<h3>%s</h3>\n<ul>\n", html.EscapeString(section.Name))
+		for _, change := range section.Changes {
+			fmt.Fprintf(&b, "<li>%s</li>\n", html.EscapeString(change))
+		}
+		b.WriteString("</ul>\n")
+	}
+	if len(entry.Changes) > 0 {
+		b.WriteString("<ul>\n")
+		for _, change := range entry.Changes {
+			fmt.Fprintf(&b, "<li>%s</li>\n", html.EscapeString(change))
+		}
+		b.WriteString("</ul>\n")
+	}
+	return b.String()
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Content atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// outputAtom writes entries as an Atom feed. Entry IDs are the source URL
+// plus the version as a fragment, which is stable across runs so consumers
+// can dedupe. Undated entries fall back to the feed's updated time.
+func outputAtom(source Source, entries []ChangelogEntry) {
+	updated := time.Now().UTC()
+	if len(entries) > 0 && !entries[0].ReleasedAt.IsZero() {
+		updated = entries[0].ReleasedAt
+	}
+
+	feed := atomFeed{
+		Title:   source.DisplayName + " changelog",
+		ID:      source.URL(),
+		Updated: updated.Format(time.RFC3339),
+		Link:    atomLink{Href: source.URL(), Rel: "alternate"},
+		Author:  atomAuthor{Name: source.DisplayName},
+	}
+
+	for _, entry := range entries {
+		entryUpdated := updated
+		if !entry.ReleasedAt.IsZero() {
+			entryUpdated = entry.ReleasedAt
+		}
+		feed.Entries = append(feed.Entries, atomEntry{
+			Title:   fmt.Sprintf("%s %s", source.DisplayName, entry.Version),
+			ID:      source.URL() + "#" + entry.Version,
+			Updated: entryUpdated.Format(time.RFC3339),
+			Link:    atomLink{Href: entryURL(source, &entry), Rel: "alternate"},
+			Content: atomContent{Type: "html", Value: entryHTML(&entry)},
+		})
+	}
+
+	writeXML(feed)
+}
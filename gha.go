@@ -0,0 +1,74 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// outputGitHubActions appends entries to the job summary and sets the version
+// (of the newest entry) and changes step outputs. Outside of Actions it falls
+// back to printing markdown.
+func outputGitHubActions(displayName string, entries []ChangelogEntry) {
+	summaryPath := os.Getenv("GITHUB_STEP_SUMMARY")
+	outputPath := os.Getenv("GITHUB_OUTPUT")
+	if summaryPath == "" && outputPath == "" {
+		fmt.Fprintf(os.Stderr, "Warning: GITHUB_STEP_SUMMARY and GITHUB_OUTPUT are not set, printing markdown instead\n")
+		for i := range entries {
+			if i > 0 {
+				fmt.Println()
+			}
+			outputMarkdown(&entries[i])
+		}
+		return
+	}
+
+	if summaryPath != "" {
+		var b strings.Builder
+		fmt.Fprintf(&b, "# %s\n\n", displayName)
+		for i := range entries {
+			writeMarkdown(&b, &entries[i])
+			b.WriteString("\n")
+		}
+		if err := appendFile(summaryPath, b.String()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing step summary: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if outputPath != "" && len(entries) > 0 {
+		var changes []string
+		for _, entry := range entries {
+			for _, section := range entry.Sections {
+				changes = append(changes, section.Changes...)
+			}
+			changes = append(changes, entry.Changes...)
+		}
+
+		// Multiline values need a heredoc-style delimiter that can't occur in
+		// the value itself
+		delim := make([]byte, 8)
+		rand.Read(delim)
+		eof := "aic_" + hex.EncodeToString(delim)
+
+		out := fmt.Sprintf("version=%s\nchanges<<%s\n%s\n%s\n", entries[0].Version, eof, strings.Join(changes, "\n"), eof)
+		if err := appendFile(outputPath, out); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing step outputs: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+func appendFile(path, s string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(s); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// gitlabSource accepts "group/project" (or "group/subgroup/project") on
+// gitlab.com, or "host/group/project" for self-hosted instances. A token
+// for private projects is read from GITLAB_TOKEN.
+func gitlabSource(spec string) (Source, error) {
+	host := "gitlab.com"
+	path := spec
+	if first, rest, ok := strings.Cut(spec, "/"); ok && strings.Contains(first, ".") {
+		host, path = first, rest
+	}
+	if !strings.Contains(path, "/") {
+		return Source{}, fmt.Errorf("invalid project '%s' (expected group/project)", spec)
+	}
+	return Source{
+		DisplayName:  path,
+		ChangelogURL: fmt.Sprintf("https://%s/%s/-/releases", host, path),
+		FetchFunc: func() ([]ChangelogEntry, error) {
+			return fetchGitLabReleases(host, path)
+		},
+	}, nil
+}
+
+func fetchGitLabReleases(host, project string) ([]ChangelogEntry, error) {
+	apiURL := fmt.Sprintf("https://%s/api/v4/projects/%s/releases", host, url.PathEscape(project))
+
+	var headers map[string]string
+	if token := os.Getenv("GITLAB_TOKEN"); token != "" {
+		headers = map[string]string{"PRIVATE-TOKEN": token}
+	}
+	body, err := fetchURLWithHeaders(apiURL, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []struct {
+		TagName         string `json:"tag_name"`
+		Description     string `json:"description"`
+		ReleasedAt      string `json:"released_at"`
+		UpcomingRelease bool   `json:"upcoming_release"`
+		Links           struct {
+			Self string `json:"self"`
+		} `json:"_links"`
+	}
+	if err := json.Unmarshal(body, &releases); err != nil {
+		return nil, fmt.Errorf("failed to parse releases: %w", err)
+	}
+
+	var entries []ChangelogEntry
+	for _, rel := range releases {
+		sections, changes := parseReleaseBody(rel.Description)
+		releasedAt, _ := time.Parse(time.RFC3339, rel.ReleasedAt)
+		entries = append(entries, ChangelogEntry{
+			Version:    strings.TrimPrefix(rel.TagName, "v"),
+			ReleasedAt: releasedAt,
+			Prerelease: rel.UpcomingRelease,
+			URL:        rel.Links.Self,
+			Sections:   sections,
+			Changes:    changes,
+			raw:        rel.Description,
+		})
+	}
+
+	return entries, nil
+}
@@ -1,3 +1,8 @@
 module github.com/arimxyer/aic
 
 go 1.25.5
+
+require (
+	github.com/Masterminds/semver/v3 v3.4.0
+	gopkg.in/yaml.v3 v3.0.1
+)
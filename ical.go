@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+// outputICal writes an iCalendar file with an all-day event on the release
+// date of each entry. Entries without a date are skipped.
+func outputICal(sourceName string, source Source, entries []ChangelogEntry) {
+	var lines []string
+	lines = append(lines,
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//aic//aic changelog//EN",
+		"CALSCALE:GREGORIAN",
+		"X-WR-CALNAME:"+icalEscaper.Replace(source.DisplayName+" releases"),
+	)
+
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	for _, entry := range entries {
+		if entry.ReleasedAt.IsZero() {
+			continue
+		}
+		var changes []string
+		for _, section := range entry.Sections {
+			changes = append(changes, section.Changes...)
+		}
+		changes = append(changes, entry.Changes...)
+
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			fmt.Sprintf("UID:%s-%s@aic", sourceName, entry.Version),
+			"DTSTAMP:"+stamp,
+			"DTSTART;VALUE=DATE:"+entry.ReleasedAt.Format("20060102"),
+			"SUMMARY:"+icalEscaper.Replace(fmt.Sprintf("%s %s", source.DisplayName, entry.Version)),
+			"DESCRIPTION:"+icalEscaper.Replace(strings.Join(changes, "\n")),
+			"URL:"+source.URL(),
+			"END:VEVENT",
+		)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, line := range lines {
+		fmt.Print(icalFold(line) + "\r\n")
+	}
+}
+
+// icalFold splits content lines longer than 75 octets as RFC 5545 requires,
+// without breaking UTF-8 sequences.
+func icalFold(line string) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range line {
+		size := len(string(r))
+		if n+size > 75 {
+			b.WriteString("\r\n ")
+			n = 1
+		}
+		b.WriteRune(r)
+		n += size
+	}
+	return b.String()
+}
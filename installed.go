@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os/exec"
+	"regexp"
+	"time"
+)
+
+var installedVersionRegex = regexp.MustCompile(`\d+\.\d+(?:\.\d+)?[\w.+-]*`)
+
+// probeTimeout bounds each local version probe, so a hung tool can't stall
+// the changelog output.
+const probeTimeout = 5 * time.Second
+
+// InstalledVersion detects the locally installed version of the source's
+// tool: first from "<Command> --version", then from the global npm
+// packages. It returns false if neither finds it.
+func (s Source) InstalledVersion() (string, bool) {
+	if s.Command != "" {
+		if out, err := runProbe(s.Command, "--version"); err == nil {
+			if ver := installedVersionRegex.FindString(string(out)); ver != "" {
+				return ver, true
+			}
+		}
+	}
+	if s.NpmPackage != "" {
+		if out, err := runProbe("npm", "ls", "-g", "--depth=0", "--json", s.NpmPackage); err == nil {
+			var ls struct {
+				Dependencies map[string]struct {
+					Version string `json:"version"`
+				} `json:"dependencies"`
+			}
+			if json.Unmarshal(out, &ls) == nil {
+				if ver := ls.Dependencies[s.NpmPackage].Version; ver != "" {
+					return ver, true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// cachedInstalledVersion is InstalledVersion for the plain view's note. The
+// result, including not finding the tool, is cached for cacheTTL like a
+// response, so plain lookups don't start a process every time.
+func (s Source) cachedInstalledVersion() (string, bool) {
+	if s.Command == "" && s.NpmPackage == "" {
+		return "", false
+	}
+	key := "installed:" + s.Command + ":" + s.NpmPackage
+	if cached, ok := readCache(key, nil); ok && cached.fresh() {
+		return string(cached.Body), len(cached.Body) > 0
+	}
+	ver, ok := s.InstalledVersion()
+	writeCache(key, nil, &cachedResponse{URL: key, FetchedAt: time.Now(), Body: []byte(ver)})
+	return ver, ok
+}
+
+func runProbe(name string, args ...string) ([]byte, error) {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+	defer cancel()
+	return exec.CommandContext(ctx, path, args...).Output()
+}
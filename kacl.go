@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// kaclCategories are the Keep a Changelog change types, in the order the
+// spec lists them.
+var kaclCategories = []string{"Added", "Changed", "Deprecated", "Removed", "Fixed", "Security"}
+
+// kaclSectionCategories maps section categories to Keep a Changelog types.
+var kaclSectionCategories = map[string]string{
+	"added":      "Added",
+	"changed":    "Changed",
+	"breaking":   "Changed",
+	"deprecated": "Deprecated",
+	"removed":    "Removed",
+	"fixed":      "Fixed",
+	"security":   "Security",
+}
+
+// kaclVerbRegexes match whole leading words, so "Address", "Newline" or
+// "Fixture" don't read as Added or Fixed.
+var kaclVerbRegexes = map[string]*regexp.Regexp{
+	"Added":      regexp.MustCompile(`(?i)^(add(ed|s|ing)?|new|feat\w*|enhancements?)\b`),
+	"Changed":    regexp.MustCompile(`(?i)^(chang\w*|improv\w*|updat\w*|perf(ormance)?|refactor\w*|breaking)\b`),
+	"Deprecated": regexp.MustCompile(`(?i)^deprecat\w*\b`),
+	"Removed":    regexp.MustCompile(`(?i)^(remov\w*|delet\w*|drop(s|ped)?)\b`),
+	"Fixed":      regexp.MustCompile(`(?i)^(fix(ed|es)?|bug\w*)\b`),
+	"Security":   regexp.MustCompile(`(?i)^secur\w*\b`),
+}
+
+// kaclCategory infers the Keep a Changelog type of a change, first from the
+// category of the section it was listed under and then from its leading verb
+// ("Fixed ...", "Add ..."). Anything unrecognized is Changed.
+func kaclCategory(sectionCategory, change string) string {
+	if category, ok := kaclSectionCategories[sectionCategory]; ok {
+		return category
+	}
+	change = strings.TrimLeft(change, "*_`[ ")
+	for _, category := range kaclCategories {
+		if kaclVerbRegexes[category].MatchString(change) {
+			return category
+		}
+	}
+	return "Changed"
+}
+
+// outputKeepAChangelog re-emits entries in Keep a Changelog format, regrouping
+// changes into its standard subsections and linking each version to its
+// release with a reference at the end.
+func outputKeepAChangelog(displayName string, entries []ChangelogEntry) {
+	fmt.Printf("# Changelog\n\n")
+	fmt.Printf("All notable changes to %s are documented in this file.\n\n", displayName)
+	fmt.Printf("The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/).\n")
+
+	for _, entry := range entries {
+		grouped := make(map[string][]string)
+		for _, section := range entry.Sections {
+			for _, change := range section.Changes {
+				category := kaclCategory(section.Category, change)
+				grouped[category] = append(grouped[category], change)
+			}
+		}
+		for _, change := range entry.Changes {
+			category := kaclCategory("", change)
+			grouped[category] = append(grouped[category], change)
+		}
+
+		if !entry.ReleasedAt.IsZero() {
+			fmt.Printf("\n## [%s] - %s\n", entry.Version, entry.ReleasedAt.Format("2006-01-02"))
+		} else {
+			fmt.Printf("\n## [%s]\n", entry.Version)
+		}
+		for _, category := range kaclCategories {
+			if len(grouped[category]) == 0 {
+				continue
+			}
+			fmt.Printf("\n### %s\n\n", category)
+			for _, change := range grouped[category] {
+				fmt.Printf("- %s\n", change)
+			}
+		}
+	}
+
+	var refs []string
+	for _, entry := range entries {
+		if entry.URL != "" {
+			refs = append(refs, fmt.Sprintf("[%s]: %s", entry.Version, entry.URL))
+		}
+	}
+	if len(refs) > 0 {
+		fmt.Printf("\n%s\n", strings.Join(refs, "\n"))
+	}
+}
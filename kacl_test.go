@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestKaclCategory(t *testing.T) {
+	tests := []struct {
+		section string
+		change  string
+		want    string
+	}{
+		{"fixed", "Add a regression test", "Fixed"},
+		{"breaking", "Drop Node 18", "Changed"},
+		{"", "Added -json output", "Added"},
+		{"", "New `watch` list", "Added"},
+		{"", "**Fix** crash on startup", "Fixed"},
+		{"", "`Deprecate` the old flag", "Deprecated"},
+		{"", "Removed legacy config", "Removed"},
+		{"", "Security: bump openssl", "Security"},
+		{"", "Faster startup", "Changed"},
+		{"", "Address crash on resume", "Changed"},
+		{"", "Newline handling in prompts", "Changed"},
+		{"", "Fixture cleanup", "Changed"},
+		{"", "Fixes login loop", "Fixed"},
+		{"", "Features: tab completion", "Added"},
+		{"other", "fix typo", "Fixed"},
+	}
+	for _, tt := range tests {
+		if got := kaclCategory(tt.section, tt.change); got != tt.want {
+			t.Errorf("kaclCategory(%q, %q) = %q, want %q", tt.section, tt.change, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// llamaCppEntries collapses llama.cpp's per-commit "bNNNN" build releases
+// into a single entry per day, named after the newest build, with one change
+// line per build. Release bodies are just the commit subject followed by
+// download links, so only the subject is kept.
+func llamaCppEntries(releases []githubRelease) []ChangelogEntry {
+	var entries []ChangelogEntry
+	for _, rel := range releases {
+		releasedAt, _ := time.Parse(time.RFC3339, rel.PublishedAt)
+		change := buildSubject(rel.Body)
+		if change == "" {
+			change = rel.Name
+		}
+		change = fmt.Sprintf("%s (%s)", change, rel.TagName)
+
+		// Builds without a date can't be grouped by day, so they keep an
+		// entry each
+		day := releasedAt.Format("2006-01-02")
+		if n := len(entries); n > 0 && !releasedAt.IsZero() && entries[n-1].ReleasedAt.Format("2006-01-02") == day {
+			entries[n-1].Changes = append(entries[n-1].Changes, change)
+			continue
+		}
+		entries = append(entries, ChangelogEntry{
+			Version:    rel.TagName,
+			ReleasedAt: releasedAt,
+			Prerelease: rel.Prerelease,
+			Changes:    []string{change},
+		})
+	}
+
+	return entries
+}
+
+// buildSubject returns the first line of prose in a llama.cpp release body,
+// skipping HTML wrappers, bold platform labels and download link bullets.
+func buildSubject(body string) string {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "",
+			strings.HasPrefix(line, "<"),
+			strings.HasPrefix(line, "**"),
+			strings.HasPrefix(line, "- "),
+			strings.HasPrefix(line, "* "),
+			strings.HasPrefix(line, "#"):
+			continue
+		}
+		return line
+	}
+	return ""
+}
@@ -2,13 +2,17 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -17,38 +21,309 @@ import (
 var version = "dev"
 
 type Section struct {
-	Name    string   `json:"name"`
-	Changes []string `json:"changes"`
+	Name     string   `json:"name"`
+	Category string   `json:"category,omitempty"`
+	Changes  []string `json:"changes"`
+
+	// PlainChanges is Changes with inline markdown stripped, alongside the
+	// markdown in JSON output
+	PlainChanges []string `json:"plain_changes,omitempty"`
 }
 
 type ChangelogEntry struct {
 	Version    string    `json:"version"`
-	ReleasedAt time.Time `json:"released_at,omitempty"`
+	ReleasedAt time.Time `json:"released_at,omitzero"`
+	Prerelease bool      `json:"prerelease,omitempty"`
+	Draft      bool      `json:"draft,omitempty"`
 	Source     string    `json:"source,omitempty"`
+	URL        string    `json:"url,omitempty"`
 	Sections   []Section `json:"sections,omitempty"`
 	Changes    []string  `json:"changes,omitempty"`
+
+	// PlainChanges is Changes with inline markdown stripped, alongside the
+	// markdown in JSON output
+	PlainChanges []string `json:"plain_changes,omitempty"`
+
+	Author          string   `json:"author,omitempty"`
+	Contributors    []string `json:"contributors,omitempty"`
+	NewContributors []string `json:"new_contributors,omitempty"`
+
+	Reactions *Reactions `json:"reactions,omitempty"`
+	Downloads int        `json:"downloads,omitempty"`
+	Refs      []Ref      `json:"refs,omitempty"`
+	Assets    []Asset    `json:"assets,omitempty"`
+	Metrics   *Metrics   `json:"metrics,omitempty"`
+	Watched   []string   `json:"watched,omitempty"`
+
+	// Unreleased marks a changelog's pending "Unreleased" section, which is
+	// kept apart from released entries.
+	Unreleased bool `json:"unreleased,omitempty"`
+
+	// heading is the changelog heading the entry was parsed from, for
+	// linking to it
+	heading string
+
+	// raw is the unparsed release notes or changelog section, for -raw
+	raw string
+}
+
+// Reactions are the emoji reaction counts on a GitHub release.
+type Reactions struct {
+	TotalCount int `json:"total_count"`
+	PlusOne    int `json:"+1"`
+	MinusOne   int `json:"-1"`
+	Laugh      int `json:"laugh"`
+	Hooray     int `json:"hooray"`
+	Confused   int `json:"confused"`
+	Heart      int `json:"heart"`
+	Rocket     int `json:"rocket"`
+	Eyes       int `json:"eyes"`
 }
 
 type Source struct {
 	DisplayName string
 	Owner       string
 	Repo        string
+
+	// TagPattern, when set, selects which release tags belong to the source
+	// and extracts the version from them (first capture group, if any).
+	// Releases whose tag doesn't match are skipped.
+	TagPattern string
+
+	// ChangelogPath is a markdown changelog file in the GitHub repo, used
+	// instead of GitHub releases when set.
+	ChangelogPath string
+
+	// ChangelogURL points at a web changelog page for sources that don't
+	// publish on GitHub at all.
+	ChangelogURL string
+
+	// VersionPattern matches version headings in a changelog file or page;
+	// its first capture group (if any) is used as the version. Left empty,
+	// entries are split at dated headings and versioned by date.
+	VersionPattern string
+
+	// FetchFunc fetches sources that need a dedicated API client. It takes
+	// precedence over all of the above.
+	FetchFunc func() ([]ChangelogEntry, error)
+
+	// ReleasesFunc builds the entries from the repo's GitHub releases itself,
+	// for repos whose releases need regrouping.
+	ReleasesFunc func([]githubRelease) []ChangelogEntry
+
+	// ChangelogFallback makes a GitHub repo without releases fall back to
+	// its CHANGELOG.md, then to its tags and commit log.
+	ChangelogFallback bool
+
+	// ReleasePages caps how many pages of 100 GitHub releases are fetched.
+	// Zero means one, which covers the usual views; full-history commands
+	// raise it with withHistory.
+	ReleasePages int
+
+	// SkipPattern drops matching change lines, e.g. dependency bumps in
+	// long auto-generated release notes.
+	SkipPattern string
+
+	// Command and NpmPackage are used to detect the installed version:
+	// "<Command> --version", then the global npm package.
+	Command    string
+	NpmPackage string
+
+	// Brew is the Homebrew formula ("--cask <name>" for casks) and
+	// SelfUpgrade the tool's own update command, used by aic upgrade.
+	Brew        string
+	SelfUpgrade string
+
+	// Custom marks sources loaded from the user's sources.yaml.
+	Custom bool
 }
 
 func (s Source) URL() string {
+	if s.ChangelogURL != "" {
+		return s.ChangelogURL
+	}
+	if s.ChangelogPath != "" {
+		return fmt.Sprintf("https://github.com/%s/%s/blob/HEAD/%s", s.Owner, s.Repo, s.ChangelogPath)
+	}
 	return fmt.Sprintf("https://github.com/%s/%s/releases", s.Owner, s.Repo)
 }
 
+// historyReleasePages is how many pages of GitHub releases full-history
+// commands (stats, archive) fetch.
+const historyReleasePages = 10
+
+// withHistory returns s set up to fetch up to historyReleasePages pages of
+// GitHub releases.
+func (s Source) withHistory() Source {
+	s.ReleasePages = historyReleasePages
+	return s
+}
+
 func (s Source) Fetch() ([]ChangelogEntry, error) {
-	return fetchGitHubReleases(s.Owner, s.Repo)
+	entries, _, err := s.FetchWithUnreleased()
+	return entries, err
+}
+
+// FetchWithUnreleased is Fetch, also returning any unreleased entries
+// found in a changelog file or page.
+func (s Source) FetchWithUnreleased() ([]ChangelogEntry, []ChangelogEntry, error) {
+	entries, err := s.fetch()
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.SkipPattern != "" {
+		if entries, err = dropChanges(entries, s.SkipPattern); err != nil {
+			return nil, nil, err
+		}
+	}
+	var released, unreleased []ChangelogEntry
+	for _, e := range mergeDuplicates(entries) {
+		// Entries without a permalink of their own link to the changelog
+		if e.URL == "" {
+			e.URL = s.URL()
+		}
+		if e.Unreleased {
+			unreleased = append(unreleased, e)
+		} else {
+			released = append(released, e)
+		}
+	}
+	return sortBySemver(released), unreleased, nil
+}
+
+// githubSource builds an ad-hoc source for any "owner/repo" on GitHub.
+func githubSource(spec string) (Source, error) {
+	owner, repo, ok := strings.Cut(spec, "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return Source{}, fmt.Errorf("invalid repository '%s' (expected owner/repo)", spec)
+	}
+	return Source{
+		DisplayName:       spec,
+		Owner:             owner,
+		Repo:              repo,
+		ChangelogFallback: true,
+	}, nil
+}
+
+// sourceTypes builds ad-hoc sources from "<type>:<arg>" source names, e.g.
+// "npm:@anthropic-ai/claude-code".
+var sourceTypes = map[string]func(arg string) (Source, error){
+	"gh":     githubSource,
+	"npm":    npmSource,
+	"pypi":   pypiSource,
+	"crate":  crateSource,
+	"vsix":   vsixSource,
+	"brew":   brewSource,
+	"gitlab": gitlabSource,
+	"docker": dockerSource,
+}
+
+// fetchGitHubReleasesOrChangelog falls back to the repo's root CHANGELOG.md
+// when it doesn't publish GitHub releases, and to its tags and commit log
+// when it has neither.
+func fetchGitHubReleasesOrChangelog(owner, repo string, pages int) ([]ChangelogEntry, error) {
+	entries, err := fetchGitHubReleases(owner, repo, "", pages)
+	if err != nil || len(entries) > 0 {
+		return entries, err
+	}
+	entries, err = fetchGitHubChangelog(owner, repo, "CHANGELOG.md", defaultVersionPattern)
+	var statusErr *httpStatusError
+	if (errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound) || (err == nil && len(entries) == 0) {
+		return fetchGitHubTagHistory(owner, repo)
+	}
+	return entries, err
+}
+
+// lookupSource resolves a built-in or custom source name, or a
+// "<type>:<arg>" ad-hoc source.
+func lookupSource(name string) (Source, bool, error) {
+	if src, ok := sources[name]; ok {
+		return src, true, nil
+	}
+	if typ, arg, ok := strings.Cut(name, ":"); ok && sourceTypes[typ] != nil {
+		src, err := sourceTypes[typ](arg)
+		return src, true, err
+	}
+	return Source{}, false, nil
+}
+
+// mustLookupSource is lookupSource for command-line arguments, exiting with
+// the list of available sources when name is unknown.
+func mustLookupSource(name string) Source {
+	src, ok, err := lookupSource(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: Unknown source '%s'\n\n", name)
+		fmt.Fprintf(os.Stderr, "Available sources:\n")
+		for name := range sources {
+			fmt.Fprintf(os.Stderr, "  %s\n", name)
+		}
+		os.Exit(1)
+	}
+	return src
+}
+
+func (s Source) fetch() ([]ChangelogEntry, error) {
+	if s.FetchFunc != nil {
+		return s.FetchFunc()
+	}
+	if s.ChangelogURL != "" {
+		return fetchHTMLChangelog(s.ChangelogURL, s.VersionPattern)
+	}
+	if s.ChangelogPath != "" {
+		return fetchGitHubChangelog(s.Owner, s.Repo, s.ChangelogPath, s.VersionPattern)
+	}
+	if s.ReleasesFunc != nil {
+		releases, err := fetchGitHubReleaseList(s.Owner, s.Repo, s.ReleasePages)
+		if err != nil {
+			return nil, err
+		}
+		return s.ReleasesFunc(releases), nil
+	}
+	if s.ChangelogFallback {
+		return fetchGitHubReleasesOrChangelog(s.Owner, s.Repo, s.ReleasePages)
+	}
+	return fetchGitHubReleases(s.Owner, s.Repo, s.TagPattern, s.ReleasePages)
 }
 
 var sources = map[string]Source{
-	"claude":   {DisplayName: "Claude Code", Owner: "anthropics", Repo: "claude-code"},
-	"codex":    {DisplayName: "OpenAI Codex", Owner: "openai", Repo: "codex"},
-	"opencode": {DisplayName: "OpenCode", Owner: "sst", Repo: "opencode"},
-	"gemini":   {DisplayName: "Gemini CLI", Owner: "google-gemini", Repo: "gemini-cli"},
-	"copilot":  {DisplayName: "GitHub Copilot CLI", Owner: "github", Repo: "copilot-cli"},
+	"claude":         {DisplayName: "Claude Code", Owner: "anthropics", Repo: "claude-code", Command: "claude", NpmPackage: "@anthropic-ai/claude-code", Brew: "--cask claude-code", SelfUpgrade: "claude update"},
+	"codex":          {DisplayName: "OpenAI Codex", Owner: "openai", Repo: "codex", Command: "codex", NpmPackage: "@openai/codex", Brew: "--cask codex"},
+	"opencode":       {DisplayName: "OpenCode", Owner: "sst", Repo: "opencode", Command: "opencode", NpmPackage: "opencode-ai", Brew: "opencode", SelfUpgrade: "opencode upgrade"},
+	"gemini":         {DisplayName: "Gemini CLI", Owner: "google-gemini", Repo: "gemini-cli", Command: "gemini", NpmPackage: "@google/gemini-cli", Brew: "gemini-cli"},
+	"copilot":        {DisplayName: "GitHub Copilot CLI", Owner: "github", Repo: "copilot-cli", Command: "copilot", NpmPackage: "@github/copilot"},
+	"aider":          {DisplayName: "Aider", Owner: "Aider-AI", Repo: "aider", ChangelogPath: "HISTORY.md", VersionPattern: `^Aider v(\d+\.\d+\.\d+)`, Command: "aider"},
+	"windsurf":       {DisplayName: "Windsurf", ChangelogURL: "https://windsurf.com/changelog", VersionPattern: `^v?(\d+\.\d+\.\d+)\b`},
+	"cline":          {DisplayName: "Cline", Owner: "cline", Repo: "cline", ChangelogPath: "CHANGELOG.md", VersionPattern: `^\[?v?(\d+\.\d+\.\d+)\]?`},
+	"roo":            {DisplayName: "Roo Code", Owner: "RooCodeInc", Repo: "Roo-Code"},
+	"continue":       {DisplayName: "Continue (VS Code)", Owner: "continuedev", Repo: "continue", TagPattern: `^v(\d+\.\d+\.\d+)-vscode$`},
+	"zed":            {DisplayName: "Zed", Owner: "zed-industries", Repo: "zed", Command: "zed", Brew: "--cask zed"},
+	"q":              {DisplayName: "Amazon Q Developer CLI", Owner: "aws", Repo: "amazon-q-developer-cli", Command: "q"},
+	"amp":            {DisplayName: "Sourcegraph Amp", ChangelogURL: "https://ampcode.com/news"},
+	"goose":          {DisplayName: "Goose", Owner: "block", Repo: "goose", TagPattern: `^v?(\d+\.\d+\.\d+)$`, Command: "goose"},
+	"warp":           {DisplayName: "Warp", ChangelogURL: "https://docs.warp.dev/getting-started/changelog", VersionPattern: `\(?v(0\.\d{4}\.\d{2}\.\d{2}\.[\w.]+?)\)?$`},
+	"jetbrains-ai":   {DisplayName: "JetBrains AI Assistant", ChangelogURL: "https://plugins.jetbrains.com/plugin/22282-jetbrains-ai-assistant/versions", FetchFunc: jetbrainsPlugin("22282")},
+	"copilot-vscode": {DisplayName: "GitHub Copilot Chat (VS Code)", ChangelogURL: "https://marketplace.visualstudio.com/items/GitHub.copilot-chat/changelog", FetchFunc: vscodeExtension("GitHub.copilot-chat")},
+	"claude-desktop": {DisplayName: "Claude Desktop", ChangelogURL: "https://support.claude.com/en/articles/12138966-release-notes"},
+	"ollama":         {DisplayName: "Ollama", Owner: "ollama", Repo: "ollama", Command: "ollama", Brew: "ollama"},
+	"lmstudio":       {DisplayName: "LM Studio", ChangelogURL: "https://lmstudio.ai/changelog", VersionPattern: `^(?:LM Studio )?v?(\d+\.\d+\.\d+)`},
+	"llamacpp":       {DisplayName: "llama.cpp", Owner: "ggml-org", Repo: "llama.cpp", ReleasesFunc: llamaCppEntries},
+	"anthropic-api":  {DisplayName: "Anthropic API", ChangelogURL: "https://docs.claude.com/en/release-notes/api"},
+	"openai-api":     {DisplayName: "OpenAI Platform", ChangelogURL: "https://platform.openai.com/docs/changelog"},
+	"gemini-api":     {DisplayName: "Gemini API", ChangelogURL: "https://ai.google.dev/gemini-api/docs/changelog"},
+	"mcp":            {DisplayName: "MCP Specification", ChangelogURL: "https://modelcontextprotocol.io/specification/latest/changelog", FetchFunc: fetchMCPSpecChangelog},
+	"vscode":         {DisplayName: "VS Code", ChangelogURL: "https://code.visualstudio.com/updates", FetchFunc: fetchVSCodeReleaseNotes, Command: "code"},
+	"crush":          {DisplayName: "Crush", Owner: "charmbracelet", Repo: "crush", Command: "crush", NpmPackage: "@charmland/crush", Brew: "charmbracelet/tap/crush"},
+	"qwen":           {DisplayName: "Qwen Code", Owner: "QwenLM", Repo: "qwen-code", Command: "qwen", NpmPackage: "@qwen-code/qwen-code"},
+	"openhands":      {DisplayName: "OpenHands", Owner: "OpenHands", Repo: "OpenHands", SkipPattern: `(?i)^(chore|build)\(deps[^)]*\)|^bump |dependabot|renovate`},
+	"kiro":           {DisplayName: "Kiro", ChangelogURL: "https://kiro.dev/changelog/"},
+	"trae":           {DisplayName: "Trae", ChangelogURL: "https://docs.trae.ai/ide/changelog", VersionPattern: `^v?(\d+\.\d+\.\d+)\b`},
+	"augment":        {DisplayName: "Augment Code", ChangelogURL: "https://www.augmentcode.com/changelog"},
+	"code-assist":    {DisplayName: "Gemini Code Assist", ChangelogURL: "https://cloud.google.com/gemini/docs/codeassist/release-notes", FetchFunc: googleCloudReleaseNotes("https://cloud.google.com/gemini/docs/codeassist/release-notes")},
+	"claude-vscode":  {DisplayName: "Claude Code for VS Code", ChangelogURL: "https://marketplace.visualstudio.com/items/anthropic.claude-code/changelog", FetchFunc: vscodeExtension("anthropic.claude-code")},
 }
 
 func main() {
@@ -64,9 +339,68 @@ func main() {
 		os.Exit(0)
 	}
 
+	for _, err := range loadCustomSources() {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to load custom sources: %v\n", err)
+	}
+
+	// Cache and network flags apply to every command, so they're taken out
+	// up front
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-no-cache", "--no-cache":
+			cacheDisabled = true
+		case "-refresh", "--refresh":
+			cacheRefresh = true
+		case "-wait", "--wait":
+			waitForRateLimit = true
+		case "-timeout", "--timeout":
+			var d time.Duration
+			var err error
+			if i+1 < len(args) {
+				d, err = time.ParseDuration(args[i+1])
+			}
+			if i+1 >= len(args) || err != nil || d <= 0 {
+				fmt.Fprintf(os.Stderr, "Error: -timeout expects a duration like 10s or 1m\n")
+				os.Exit(1)
+			}
+			httpClient.Timeout = d
+			i++
+		case "-retries", "--retries":
+			var n int
+			var err error
+			if i+1 < len(args) {
+				n, err = strconv.Atoi(args[i+1])
+			}
+			if i+1 >= len(args) || err != nil || n < 0 {
+				fmt.Fprintf(os.Stderr, "Error: -retries expects a number of retries, e.g. 0 to disable them\n")
+				os.Exit(1)
+			}
+			maxRetries = n
+			i++
+		default:
+			rest = append(rest, args[i])
+		}
+	}
+	args = rest
+	if len(args) == 0 {
+		printUsage()
+		os.Exit(0)
+	}
+
 	if args[0] == "list-sources" {
-		for name, src := range sources {
-			fmt.Printf("  %s\t%s\n", name, src.DisplayName)
+		names := make([]string, 0, len(sources))
+		for name := range sources {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			src := sources[name]
+			if src.Custom {
+				fmt.Printf("  %s\t%s (custom)\n", name, src.DisplayName)
+			} else {
+				fmt.Printf("  %s\t%s\n", name, src.DisplayName)
+			}
 		}
 		os.Exit(0)
 	}
@@ -111,28 +445,292 @@ func main() {
 		os.Exit(0)
 	}
 
-	sourceName := args[0]
-	source, ok := sources[sourceName]
-	if !ok {
-		fmt.Fprintf(os.Stderr, "Error: Unknown source '%s'\n\n", sourceName)
-		fmt.Fprintf(os.Stderr, "Available sources:\n")
-		for name := range sources {
-			fmt.Fprintf(os.Stderr, "  %s\n", name)
+	if args[0] == "opml" {
+		var base string
+		for i := 1; i < len(args); i++ {
+			switch args[i] {
+			case "-base", "--base":
+				if i+1 < len(args) {
+					base = args[i+1]
+					i++
+				}
+			}
 		}
-		os.Exit(1)
+		runOPMLCommand(base)
+		os.Exit(0)
+	}
+
+	if args[0] == "outdated" {
+		var jsonOutput bool
+		for _, arg := range args[1:] {
+			if arg == "-json" || arg == "--json" {
+				jsonOutput = true
+			}
+		}
+		runOutdatedCommand(jsonOutput)
+		os.Exit(0)
+	}
+
+	if args[0] == "upgrade" {
+		if len(args) < 2 {
+			fmt.Fprintf(os.Stderr, "Usage: aic upgrade <source> [-x]\n")
+			os.Exit(1)
+		}
+		var execute bool
+		for _, arg := range args[2:] {
+			if arg == "-x" {
+				execute = true
+			}
+		}
+		runUpgradeCommand(mustLookupSource(args[1]), execute)
+		os.Exit(0)
+	}
+
+	if args[0] == "check" {
+		if len(args) < 2 {
+			fmt.Fprintf(os.Stderr, "Usage: aic check <source> [-since-file <file>] [-since <ver>]\n")
+			os.Exit(1)
+		}
+		var stateFile, sinceVersion string
+		for i := 2; i < len(args); i++ {
+			switch args[i] {
+			case "-since-file", "--since-file":
+				if i+1 < len(args) {
+					stateFile = args[i+1]
+					i++
+				}
+			case "-since", "--since":
+				if i+1 < len(args) {
+					sinceVersion = args[i+1]
+					i++
+				}
+			}
+		}
+		if stateFile == "" && sinceVersion == "" {
+			fmt.Fprintf(os.Stderr, "Error: check needs -since-file <file> or -since <ver>\n")
+			os.Exit(1)
+		}
+		runCheckCommand(args[1], mustLookupSource(args[1]), stateFile, sinceVersion)
+	}
+
+	if args[0] == "stats" {
+		if len(args) < 2 {
+			fmt.Fprintf(os.Stderr, "Usage: aic stats <source> [-json]\n")
+			os.Exit(1)
+		}
+		var jsonOutput bool
+		for _, arg := range args[2:] {
+			if arg == "-json" || arg == "--json" {
+				jsonOutput = true
+			}
+		}
+		runStatsCommand(mustLookupSource(args[1]), jsonOutput)
+		os.Exit(0)
+	}
+
+	if args[0] == "compare" {
+		var jsonOutput bool
+		for _, arg := range args[1:] {
+			if arg == "-json" || arg == "--json" {
+				jsonOutput = true
+			}
+		}
+		runCompareCommand(jsonOutput)
+		os.Exit(0)
+	}
+
+	if args[0] == "site" {
+		outDir := "public"
+		for i := 1; i < len(args); i++ {
+			switch args[i] {
+			case "-o":
+				if i+1 < len(args) {
+					outDir = args[i+1]
+					i++
+				}
+			}
+		}
+		runSiteCommand(outDir)
+		os.Exit(0)
+	}
+
+	if args[0] == "archive" {
+		outDir := "changelogs"
+		for i := 1; i < len(args); i++ {
+			switch args[i] {
+			case "-o":
+				if i+1 < len(args) {
+					outDir = args[i+1]
+					i++
+				}
+			}
+		}
+		runArchiveCommand(outDir)
+		os.Exit(0)
+	}
+
+	if args[0] == "search" {
+		var term string
+		var jsonOutput bool
+		for i := 1; i < len(args); i++ {
+			switch args[i] {
+			case "-json", "--json":
+				jsonOutput = true
+			default:
+				term = args[i]
+			}
+		}
+		if term == "" {
+			fmt.Fprintf(os.Stderr, "Usage: aic search <term> [-json]\n")
+			os.Exit(1)
+		}
+		runSearchCommand(term, jsonOutput)
+		os.Exit(0)
+	}
+
+	if args[0] == "when" {
+		var rest []string
+		var jsonOutput bool
+		for _, arg := range args[1:] {
+			if arg == "-json" || arg == "--json" {
+				jsonOutput = true
+			} else {
+				rest = append(rest, arg)
+			}
+		}
+		if len(rest) != 2 {
+			fmt.Fprintf(os.Stderr, "Usage: aic when <source> <term> [-json]\n")
+			os.Exit(1)
+		}
+		runWhenCommand(mustLookupSource(rest[0]), rest[1], jsonOutput)
+		os.Exit(0)
+	}
+
+	if args[0] == "assets" {
+		if len(args) < 2 {
+			fmt.Fprintf(os.Stderr, "Usage: aic assets <source> [-version <ver>] [-download <pattern>] [-o <dir>] [-verify]\n")
+			os.Exit(1)
+		}
+		source := mustLookupSource(args[1])
+		var targetVersion, pattern, minisignKey string
+		var verify bool
+		outDir := "."
+		for i := 2; i < len(args); i++ {
+			switch args[i] {
+			case "-version", "--version":
+				if i+1 < len(args) {
+					targetVersion = args[i+1]
+					i++
+				}
+			case "-download", "--download":
+				if i+1 < len(args) {
+					pattern = args[i+1]
+					i++
+				}
+			case "-verify", "--verify":
+				verify = true
+			case "-minisign-key", "--minisign-key":
+				if i+1 < len(args) {
+					minisignKey = args[i+1]
+					i++
+				}
+			case "-o":
+				if i+1 < len(args) {
+					outDir = args[i+1]
+					i++
+				}
+			}
+		}
+		if verify && pattern == "" {
+			fmt.Fprintf(os.Stderr, "Error: -verify checks downloaded assets; pass -download <pattern>\n")
+			os.Exit(1)
+		}
+		runAssetsCommand(source, targetVersion, pattern, outDir, verify, minisignKey)
+		os.Exit(0)
 	}
 
-	var jsonOutput, mdOutput, listVersions, webOpen bool
-	var targetVersion string
+	if args[0] == "badge" {
+		if len(args) < 2 {
+			fmt.Fprintf(os.Stderr, "Usage: aic badge <source> [-o <file>] [-json] [-color <color>]\n")
+			os.Exit(1)
+		}
+		source := mustLookupSource(args[1])
+		var outPath string
+		var jsonOutput bool
+		color := badgeColor
+		for i := 2; i < len(args); i++ {
+			switch args[i] {
+			case "-o":
+				if i+1 < len(args) {
+					outPath = args[i+1]
+					i++
+				}
+			case "-json", "--json":
+				jsonOutput = true
+			case "-color", "--color":
+				if i+1 < len(args) {
+					color = args[i+1]
+					i++
+				}
+			}
+		}
+		runBadgeCommand(source, outPath, color, jsonOutput)
+		os.Exit(0)
+	}
+
+	// Several source names, or "all", show the sources side by side
+	if args[0] != "gh" {
+		var names []string
+		for _, arg := range args {
+			if strings.HasPrefix(arg, "-") {
+				break
+			}
+			names = append(names, arg)
+		}
+		if len(names) > 1 || args[0] == "all" {
+			runMultiCommand(names, args[len(names):])
+			os.Exit(0)
+		}
+	}
+
+	var source Source
+	if args[0] == "gh" {
+		if len(args) < 2 {
+			fmt.Fprintf(os.Stderr, "Usage: aic gh <owner>/<repo> [flags]\n")
+			os.Exit(1)
+		}
+		var err error
+		if source, err = githubSource(args[1]); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		// Parse the remaining flags as if owner/repo were the source name
+		args = args[1:]
+	} else {
+		source = mustLookupSource(args[0])
+	}
+
+	sourceName := args[0]
+	var allEntries, listVersions, versionOnly, webOpen, ignoreCase, includePre, preOnly, plainText, keepMarkdown, showUnreleased, detectInstalled, showSummary, watchOnly bool
+	var count int
+	var maxAge time.Duration
+	var after, before time.Time
+	var format, outPath, targetVersion, sinceVersion, installedVersion, fromVersion, toVersion, diffRange, channel, templateText, grepPattern string
 
 	for i := 1; i < len(args); i++ {
 		switch args[i] {
-		case "-json", "--json":
-			jsonOutput = true
-		case "-md", "--md":
-			mdOutput = true
+		case "-json", "--json", "-jsonl", "--jsonl", "-md", "--md", "-rss", "--rss",
+			"-atom", "--atom", "-csv", "--csv", "-tsv", "--tsv", "-pretty", "--pretty",
+			"-slack", "--slack", "-discord", "--discord",
+			"-teams", "--teams", "-gha", "--gha", "-credits", "--credits", "-adoc", "--adoc",
+			"-kacl", "--kacl", "-ical", "--ical", "-html", "--html":
+			format = strings.TrimLeft(args[i], "-")
+		case "-all", "--all":
+			allEntries = true
 		case "-list", "--list":
 			listVersions = true
+		case "-latest", "--latest", "-q":
+			versionOnly = true
 		case "-web", "--web":
 			webOpen = true
 		case "-version", "--version":
@@ -140,133 +738,607 @@ func main() {
 				targetVersion = args[i+1]
 				i++
 			}
+		case "-since", "--since":
+			if i+1 < len(args) {
+				sinceVersion = args[i+1]
+				i++
+			}
+		case "-installed", "--installed":
+			// Without a version, detect the installed one
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				installedVersion = args[i+1]
+				i++
+			} else {
+				detectInstalled = true
+			}
+		case "-n":
+			if i+1 < len(args) {
+				n, err := strconv.Atoi(args[i+1])
+				if err != nil || n < 1 {
+					fmt.Fprintf(os.Stderr, "Error: -n expects a positive number, got '%s'\n", args[i+1])
+					os.Exit(1)
+				}
+				count = n
+				i++
+			}
+		case "-max-age", "--max-age":
+			if i+1 < len(args) {
+				d, err := parseAge(args[i+1])
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: -max-age expects an age like 30d, 2w or 12h, got '%s'\n", args[i+1])
+					os.Exit(1)
+				}
+				maxAge = d
+				i++
+			}
+		case "-after", "--after", "-before", "--before":
+			if i+1 < len(args) {
+				date, err := time.Parse("2006-01-02", args[i+1])
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error: %s expects a YYYY-MM-DD date, got '%s'\n", args[i], args[i+1])
+					os.Exit(1)
+				}
+				if strings.HasSuffix(args[i], "after") {
+					after = date
+				} else {
+					before = date
+				}
+				i++
+			}
+		case "-grep", "--grep":
+			// Allow grep's own "-grep -i <pattern>" order
+			if i+2 < len(args) && args[i+1] == "-i" {
+				ignoreCase = true
+				i++
+			}
+			if i+1 < len(args) {
+				grepPattern = args[i+1]
+				i++
+			}
+		case "-i":
+			ignoreCase = true
+		case "-diff", "--diff":
+			if i+1 < len(args) {
+				diffRange = args[i+1]
+				i++
+			}
+		case "-from", "--from":
+			if i+1 < len(args) {
+				fromVersion = args[i+1]
+				i++
+			}
+		case "-to", "--to":
+			if i+1 < len(args) {
+				toVersion = args[i+1]
+				i++
+			}
+		case "-watchlist-only", "--watchlist-only":
+			watchOnly = true
+		case "-o":
+			if i+1 < len(args) {
+				outPath = args[i+1]
+				i++
+			}
+		case "-raw", "--raw":
+			format = "raw"
+		case "-summary", "--summary":
+			showSummary = true
+		case "-plain", "--plain":
+			plainText = true
+		case "-keep-md", "--keep-md":
+			keepMarkdown = true
+		case "-unreleased", "--unreleased":
+			showUnreleased = true
+		case "-pre", "--pre":
+			includePre = true
+		case "-pre-only", "--pre-only":
+			preOnly = true
+		case "-channel", "--channel":
+			if i+1 < len(args) {
+				channel = args[i+1]
+				i++
+			}
+		case "-template", "--template":
+			if i+1 < len(args) {
+				format = "template"
+				templateText = args[i+1]
+				i++
+			}
+		case "-template-file", "--template-file":
+			if i+1 < len(args) {
+				data, err := os.ReadFile(args[i+1])
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error reading template: %v\n", err)
+					os.Exit(1)
+				}
+				format = "template"
+				templateText = string(data)
+				i++
+			}
+		}
+	}
+
+	if outPath != "" && format == "" {
+		format = outputFormats[strings.ToLower(filepath.Ext(outPath))]
+	}
+
+	if channel != "" && channel != "stable" && channel != "preview" {
+		fmt.Fprintf(os.Stderr, "Error: Unknown channel '%s' (expected stable or preview)\n", channel)
+		os.Exit(1)
+	}
+
+	if detectInstalled {
+		ver, ok := source.InstalledVersion()
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Error: Couldn't detect the installed version of %s; pass -installed <ver>\n", source.DisplayName)
+			os.Exit(1)
+		}
+		installedVersion = ver
+	}
+
+	if webOpen {
+		openBrowser(source.URL())
+		os.Exit(0)
+	}
+
+	entries, unreleased, err := source.FetchWithUnreleased()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching changelog: %v\n", err)
+		os.Exit(1)
+	}
+	if showUnreleased {
+		if len(unreleased) == 0 {
+			fmt.Fprintf(os.Stderr, "No unreleased changes in %s\n", source.DisplayName)
+			os.Exit(1)
+		}
+		entries = unreleased
+	}
+
+	// Prereleases are hidden unless asked for, or unless the source has
+	// nothing else. Asking for a specific version searches everything.
+	switch {
+	case preOnly || channel == "preview":
+		entries = filterChannel(entries, true)
+	case channel == "stable":
+		entries = filterChannel(entries, false)
+	case !includePre && targetVersion == "":
+		if stable := filterChannel(entries, false); len(stable) > 0 {
+			entries = stable
+		}
+	}
+
+	if !after.IsZero() || !before.IsZero() {
+		entries = filterDates(entries, after, before)
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: No changelog entries found\n")
+		os.Exit(1)
+	}
+
+	if maxAge > 0 {
+		latest := entries[0].ReleasedAt
+		if latest.IsZero() {
+			fmt.Fprintf(os.Stderr, "Error: %s %s has no release date\n", source.DisplayName, entries[0].Version)
+			os.Exit(1)
+		}
+		if time.Since(latest) > maxAge {
+			fmt.Fprintf(os.Stderr, "Error: %s hasn't released since %s (%s)\n", source.DisplayName, latest.Format("2006-01-02"), formatRelativeTime(latest))
+			os.Exit(1)
+		}
+	}
+
+	if listVersions {
+		for _, entry := range entries {
+			fmt.Println(entry.Version)
+		}
+		os.Exit(0)
+	}
+
+	if versionOnly {
+		fmt.Println(entries[0].Version)
+		os.Exit(0)
+	}
+
+	var entry *ChangelogEntry
+	if targetVersion != "" {
+		i := versionIndex(entries, targetVersion)
+		if i < 0 {
+			fmt.Fprintf(os.Stderr, "Error: Version %s not found\n", targetVersion)
+			os.Exit(1)
+		}
+		entry = &entries[i]
+	} else {
+		entry = &entries[0]
+	}
+
+	// multiple is set when the selection can hold any number of entries, so
+	// formats that otherwise show a single entry know to show them all
+	selected := []ChangelogEntry{*entry}
+	var multiple bool
+	// -installed is -since, phrased as what upgrading would bring
+	if installedVersion != "" && sinceVersion == "" {
+		sinceVersion = installedVersion
+	}
+	if sinceVersion != "" {
+		// A version that isn't listed (e.g. filtered out as a prerelease)
+		// can still be compared as semver
+		if i := versionIndex(entries, sinceVersion); i >= 0 {
+			selected = entries[:i]
+		} else if selected, err = newerThan(entries, sinceVersion); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Version %s not found\n", sinceVersion)
+			os.Exit(1)
+		}
+		multiple = true
+		switch {
+		case installedVersion != "" && len(selected) > 0:
+			fmt.Fprintf(os.Stderr, "installed %s → latest %s (%d new)\n", installedVersion, selected[0].Version, len(selected))
+		case installedVersion != "":
+			fmt.Fprintf(os.Stderr, "%s %s is up to date\n", source.DisplayName, installedVersion)
+		case len(selected) == 0:
+			fmt.Fprintf(os.Stderr, "No entries newer than %s\n", sinceVersion)
+		}
+	}
+	if fromVersion != "" || toVersion != "" {
+		// Entries are newest first, so -from is the higher index
+		from, to := len(entries)-1, 0
+		if fromVersion != "" {
+			if from = versionIndex(entries, fromVersion); from < 0 {
+				fmt.Fprintf(os.Stderr, "Error: Version %s not found\n", fromVersion)
+				os.Exit(1)
+			}
+		}
+		if toVersion != "" {
+			if to = versionIndex(entries, toVersion); to < 0 {
+				fmt.Fprintf(os.Stderr, "Error: Version %s not found\n", toVersion)
+				os.Exit(1)
+			}
+		}
+		if to > from {
+			from, to = to, from
+		}
+		selected = entries[to : from+1]
+		multiple = true
+	}
+	if allEntries {
+		selected = entries
+		multiple = true
+	}
+	// -n on its own picks the newest entries, otherwise it caps the selection
+	if count > 0 {
+		if !multiple {
+			selected = entries
+		}
+		selected = selected[:min(count, len(selected))]
+		multiple = true
+	}
+
+	// -diff A..B rolls everything after A up to and including B into one
+	// entry; B defaults to the latest version
+	if diffRange != "" {
+		fromVer, toVer, ok := strings.Cut(diffRange, "..")
+		if !ok || fromVer == "" {
+			fmt.Fprintf(os.Stderr, "Error: -diff expects <from>..<to>, got '%s'\n", diffRange)
+			os.Exit(1)
+		}
+		from, to := versionIndex(entries, fromVer), 0
+		if from < 0 {
+			fmt.Fprintf(os.Stderr, "Error: Version %s not found\n", fromVer)
+			os.Exit(1)
+		}
+		if toVer != "" {
+			if to = versionIndex(entries, toVer); to < 0 {
+				fmt.Fprintf(os.Stderr, "Error: Version %s not found\n", toVer)
+				os.Exit(1)
+			}
+		} else {
+			toVer = entries[0].Version
 		}
+		if to > from {
+			from, to = to, from
+			fromVer, toVer = toVer, fromVer
+		}
+		if from == to {
+			fmt.Fprintf(os.Stderr, "No changes between %s and %s\n", fromVer, toVer)
+			os.Exit(1)
+		}
+		selected = []ChangelogEntry{consolidateEntries(fromVer+".."+toVer, source.URL(), entries[to:from])}
+		entry = &selected[0]
+		multiple = false
 	}
 
-	if webOpen {
-		openBrowser(source.URL())
-		os.Exit(0)
+	if grepPattern != "" {
+		if ignoreCase {
+			grepPattern = "(?i)" + grepPattern
+		}
+		re, err := regexp.Compile(grepPattern)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Invalid -grep pattern: %v\n", err)
+			os.Exit(1)
+		}
+		selected = grepChanges(selected, re)
+		if len(selected) == 0 {
+			fmt.Fprintf(os.Stderr, "No changes match %s\n", grepPattern)
+			os.Exit(1)
+		}
+		entry = &selected[0]
 	}
 
-	entries, err := source.Fetch()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error fetching changelog: %v\n", err)
-		os.Exit(1)
+	if watchOnly {
+		re := watchRegex()
+		if re == nil {
+			fmt.Fprintf(os.Stderr, "Error: -watchlist-only needs a watch list in sources.yaml\n")
+			os.Exit(1)
+		}
+		if selected = grepChanges(selected, re); len(selected) == 0 {
+			fmt.Fprintf(os.Stderr, "No changes mention a watched keyword\n")
+			os.Exit(1)
+		}
+		entry = &selected[0]
 	}
 
-	if len(entries) == 0 {
-		fmt.Fprintf(os.Stderr, "Error: No changelog entries found\n")
-		os.Exit(1)
+	// Plain text output reads better without inline markdown; other formats
+	// keep it unless asked, and JSON has both
+	stripped := (format == "" && !keepMarkdown) || plainText
+	if stripped && len(selected) > 0 {
+		selected = stripEntriesMarkdown(selected)
+		entry = &selected[0]
 	}
 
-	if listVersions {
-		for _, entry := range entries {
-			fmt.Println(entry.Version)
-		}
-		os.Exit(0)
+	// Plain text only shows metrics when asked
+	if (format != "" || showSummary) && len(selected) > 0 {
+		addMetrics(selected)
+		entry = &selected[0]
+	}
+	if len(selected) > 0 {
+		markWatched(selected, format != "csv" && format != "tsv" && format != "kacl" && format != "template")
+		entry = &selected[0]
+	}
+	if (format == "json" || format == "jsonl") && !stripped && len(selected) > 0 {
+		selected = addPlainChanges(selected)
+		entry = &selected[0]
 	}
 
-	var entry *ChangelogEntry
-	if targetVersion != "" {
-		for i := range entries {
-			if entries[i].Version == targetVersion {
-				entry = &entries[i]
-				break
+	// The plain view of the latest entry notes the locally installed version
+	if format == "" && !multiple && targetVersion == "" && diffRange == "" {
+		if ver, ok := source.cachedInstalledVersion(); ok {
+			if normalizeVersion(ver) == normalizeVersion(entries[0].Version) {
+				fmt.Fprintf(os.Stderr, "installed %s (latest)\n", ver)
+			} else {
+				fmt.Fprintf(os.Stderr, "installed %s → latest %s\n", ver, entries[0].Version)
 			}
 		}
-		if entry == nil {
-			fmt.Fprintf(os.Stderr, "Error: Version %s not found\n", targetVersion)
+	}
+
+	// Every format writes to stdout, so -o swaps it for the file
+	if outPath != "" {
+		f, err := os.Create(outPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-	} else {
-		entry = &entries[0]
+		defer f.Close()
+		os.Stdout = f
 	}
 
-	if jsonOutput {
-		outputJSON(entry)
-	} else if mdOutput {
-		outputMarkdown(entry)
-	} else {
-		outputPlainText(source.DisplayName, entry)
+	switch format {
+	case "raw":
+		for i, e := range selected {
+			if e.raw == "" {
+				fmt.Fprintf(os.Stderr, "Error: No raw release notes for %s %s\n", source.DisplayName, e.Version)
+				os.Exit(1)
+			}
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Println(strings.TrimSpace(e.raw))
+		}
+	case "rss":
+		outputRSS(sourceName, source, selected)
+	case "atom":
+		outputAtom(source, selected)
+	case "ical":
+		outputICal(sourceName, source, selected)
+	case "csv", "tsv":
+		outputCSV(source.DisplayName, selected, format == "tsv")
+	case "jsonl":
+		outputJSONLines(source.DisplayName, selected)
+	case "adoc":
+		outputAsciiDoc(source.DisplayName, selected)
+	case "kacl":
+		outputKeepAChangelog(source.DisplayName, selected)
+	case "template":
+		outputTemplate(source.DisplayName, templateText, selected)
+	case "json":
+		if multiple {
+			writeJSON(selected)
+		} else {
+			outputJSON(entry)
+		}
+	case "html":
+		outputHTML(source, selected)
+	case "md":
+		for i := range selected {
+			if i > 0 {
+				fmt.Println()
+			}
+			outputMarkdown(&selected[i])
+		}
+	case "pretty":
+		for i := range selected {
+			if i > 0 {
+				fmt.Println()
+			}
+			outputPretty(source.DisplayName, &selected[i])
+		}
+	case "slack":
+		outputSlack(source, selected)
+	case "discord":
+		outputDiscord(source, selected)
+	case "teams":
+		outputTeams(source, selected)
+	case "gha":
+		outputGitHubActions(source.DisplayName, selected)
+	case "credits":
+		outputCredits(source.DisplayName, selected)
+	default:
+		for i := range selected {
+			if i > 0 {
+				fmt.Println()
+			}
+			outputPlainText(source.DisplayName, &selected[i])
+		}
 	}
 }
 
 func printUsage() {
 	fmt.Fprintf(os.Stderr, "aic - AI Coding Agent Changelog Viewer\n\n")
 	fmt.Fprintf(os.Stderr, "Usage: aic <source> [flags]\n")
+	fmt.Fprintf(os.Stderr, "       aic gh <owner>/<repo> [flags]\n")
+	fmt.Fprintf(os.Stderr, "       aic <type>:<name> [flags]\n")
+	fmt.Fprintf(os.Stderr, "       aic <source> <source>... [flags]\n")
+	fmt.Fprintf(os.Stderr, "       aic all [flags]\n")
 	fmt.Fprintf(os.Stderr, "       aic latest [flags]\n")
 	fmt.Fprintf(os.Stderr, "       aic status [flags]\n\n")
 	fmt.Fprintf(os.Stderr, "Sources:\n")
-	fmt.Fprintf(os.Stderr, "  claude      Claude Code (Anthropic)\n")
-	fmt.Fprintf(os.Stderr, "  codex       Codex CLI (OpenAI)\n")
-	fmt.Fprintf(os.Stderr, "  opencode    OpenCode (SST)\n")
-	fmt.Fprintf(os.Stderr, "  gemini      Gemini CLI (Google)\n")
-	fmt.Fprintf(os.Stderr, "  copilot     Copilot CLI (GitHub)\n\n")
+	fmt.Fprintf(os.Stderr, "  claude          Claude Code (Anthropic)\n")
+	fmt.Fprintf(os.Stderr, "  codex           Codex CLI (OpenAI)\n")
+	fmt.Fprintf(os.Stderr, "  opencode        OpenCode (SST)\n")
+	fmt.Fprintf(os.Stderr, "  gemini          Gemini CLI (Google)\n")
+	fmt.Fprintf(os.Stderr, "  copilot         Copilot CLI (GitHub)\n")
+	fmt.Fprintf(os.Stderr, "  windsurf        Windsurf (Codeium)\n")
+	fmt.Fprintf(os.Stderr, "  aider           Aider (Aider-AI)\n")
+	fmt.Fprintf(os.Stderr, "  cline           Cline (Cline)\n")
+	fmt.Fprintf(os.Stderr, "  roo             Roo Code (Roo Code)\n")
+	fmt.Fprintf(os.Stderr, "  continue        Continue VS Code extension (Continue.dev)\n")
+	fmt.Fprintf(os.Stderr, "  zed             Zed (Zed Industries)\n")
+	fmt.Fprintf(os.Stderr, "  q               Amazon Q Developer CLI (AWS)\n")
+	fmt.Fprintf(os.Stderr, "  amp             Amp (Sourcegraph)\n")
+	fmt.Fprintf(os.Stderr, "  goose           Goose (Block)\n")
+	fmt.Fprintf(os.Stderr, "  warp            Warp (Warp)\n")
+	fmt.Fprintf(os.Stderr, "  jetbrains-ai    JetBrains AI Assistant (JetBrains)\n")
+	fmt.Fprintf(os.Stderr, "  copilot-vscode  Copilot Chat VS Code extension (GitHub)\n")
+	fmt.Fprintf(os.Stderr, "  claude-desktop  Claude Desktop (Anthropic)\n")
+	fmt.Fprintf(os.Stderr, "  ollama          Ollama (Ollama)\n")
+	fmt.Fprintf(os.Stderr, "  lmstudio        LM Studio (Element Labs)\n")
+	fmt.Fprintf(os.Stderr, "  llamacpp        llama.cpp (ggml-org)\n")
+	fmt.Fprintf(os.Stderr, "  anthropic-api   Anthropic API release notes (Anthropic)\n")
+	fmt.Fprintf(os.Stderr, "  openai-api      OpenAI platform changelog (OpenAI)\n")
+	fmt.Fprintf(os.Stderr, "  gemini-api      Gemini API release notes (Google)\n")
+	fmt.Fprintf(os.Stderr, "  mcp             Model Context Protocol spec (MCP)\n")
+	fmt.Fprintf(os.Stderr, "  vscode          VS Code release notes (Microsoft)\n")
+	fmt.Fprintf(os.Stderr, "  crush           Crush (Charmbracelet)\n")
+	fmt.Fprintf(os.Stderr, "  qwen            Qwen Code (Alibaba)\n")
+	fmt.Fprintf(os.Stderr, "  openhands       OpenHands (All Hands AI)\n")
+	fmt.Fprintf(os.Stderr, "  kiro            Kiro (AWS)\n")
+	fmt.Fprintf(os.Stderr, "  trae            Trae (ByteDance)\n")
+	fmt.Fprintf(os.Stderr, "  augment         Augment Code (Augment)\n")
+	fmt.Fprintf(os.Stderr, "  code-assist     Gemini Code Assist (Google)\n")
+	fmt.Fprintf(os.Stderr, "  claude-vscode   Claude Code VS Code extension (Anthropic)\n\n")
 	fmt.Fprintf(os.Stderr, "Commands:\n")
-	fmt.Fprintf(os.Stderr, "  latest             Show releases from all sources in last 24h\n")
-	fmt.Fprintf(os.Stderr, "  status             Show status table of all sources\n\n")
+	fmt.Fprintf(os.Stderr, "  latest                  Show releases from all sources in last 24h\n")
+	fmt.Fprintf(os.Stderr, "  status                  Show status table of all sources\n")
+	fmt.Fprintf(os.Stderr, "  compare [-json]         Latest version, date and size of every source side by side\n")
+	fmt.Fprintf(os.Stderr, "  list-sources            List built-in and custom sources\n")
+	fmt.Fprintf(os.Stderr, "  outdated [-json]        Installed tools with newer releases\n")
+	fmt.Fprintf(os.Stderr, "  upgrade <source> [-x]   Print (or run) the command that upgrades a tool\n")
+	fmt.Fprintf(os.Stderr, "  check <source>          Exit 2 if there's a release newer than -since-file/-since\n")
+	fmt.Fprintf(os.Stderr, "  stats <source>          Release cadence and biggest releases over the full history\n")
+	fmt.Fprintf(os.Stderr, "  search <term>           Search every source's changes for a term\n")
+	fmt.Fprintf(os.Stderr, "  when <source> <term>    Earliest version mentioning a term\n")
+	fmt.Fprintf(os.Stderr, "  opml [-base <url>]      Export an OPML list of every source's feed\n")
+	fmt.Fprintf(os.Stderr, "  site [-o <dir>]         Generate a static HTML site of all sources\n")
+	fmt.Fprintf(os.Stderr, "  archive [-o <dir>]      Write every source's full history as markdown and JSON\n")
+	fmt.Fprintf(os.Stderr, "  assets <source>         List release assets (-version, -download <glob>, -o <dir>)\n")
+	fmt.Fprintf(os.Stderr, "                          -verify checks downloads against published checksums\n")
+	fmt.Fprintf(os.Stderr, "  badge <source>          Latest version badge as SVG (-o <file>, -json, -color)\n\n")
+	fmt.Fprintf(os.Stderr, "Source types:\n")
+	fmt.Fprintf(os.Stderr, "  gh:<owner>/<repo>       GitHub releases (or CHANGELOG.md)\n")
+	fmt.Fprintf(os.Stderr, "  npm:<package>           npm registry versions\n")
+	fmt.Fprintf(os.Stderr, "  pypi:<package>          PyPI release history\n")
+	fmt.Fprintf(os.Stderr, "  crate:<name>            crates.io versions\n")
+	fmt.Fprintf(os.Stderr, "  vsix:<pub>.<ext>        VS Code Marketplace extension\n")
+	fmt.Fprintf(os.Stderr, "  brew:<formula>          Homebrew packaged version\n")
+	fmt.Fprintf(os.Stderr, "  gitlab:<project>        GitLab releases ([host/]group/project)\n")
+	fmt.Fprintf(os.Stderr, "  docker:<image>          Docker Hub or GHCR image tags\n\n")
 	fmt.Fprintf(os.Stderr, "Flags:\n")
-	fmt.Fprintf(os.Stderr, "  -json              Output as JSON\n")
-	fmt.Fprintf(os.Stderr, "  -md                Output as markdown\n")
-	fmt.Fprintf(os.Stderr, "  -list              List all versions\n")
-	fmt.Fprintf(os.Stderr, "  -version <ver>     Get specific version\n")
-	fmt.Fprintf(os.Stderr, "  -web               Open changelog source in browser\n")
-	fmt.Fprintf(os.Stderr, "  -v, --version      Show aic version\n")
-	fmt.Fprintf(os.Stderr, "  -h, --help         Show this help\n\n")
+	fmt.Fprintf(os.Stderr, "  -json                   Output as JSON\n")
+	fmt.Fprintf(os.Stderr, "  -jsonl                  Output as JSON Lines (one entry per line)\n")
+	fmt.Fprintf(os.Stderr, "  -md                     Output as markdown\n")
+	fmt.Fprintf(os.Stderr, "  -adoc                   Output as AsciiDoc\n")
+	fmt.Fprintf(os.Stderr, "  -kacl                   Output in Keep a Changelog format\n")
+	fmt.Fprintf(os.Stderr, "  -pretty                 Render markdown with colors for the terminal\n")
+	fmt.Fprintf(os.Stderr, "  -html                   Output as a standalone HTML page\n")
+	fmt.Fprintf(os.Stderr, "  -rss                    Output as an RSS 2.0 feed\n")
+	fmt.Fprintf(os.Stderr, "  -atom                   Output as an Atom feed\n")
+	fmt.Fprintf(os.Stderr, "  -ical                   Output release dates as an iCalendar file\n")
+	fmt.Fprintf(os.Stderr, "  -csv, -tsv              Output one row per change as CSV/TSV\n")
+	fmt.Fprintf(os.Stderr, "  -slack                  Output as a Slack Block Kit webhook payload\n")
+	fmt.Fprintf(os.Stderr, "  -discord                Output as a Discord webhook embed\n")
+	fmt.Fprintf(os.Stderr, "  -teams                  Output as a Microsoft Teams Adaptive Card\n")
+	fmt.Fprintf(os.Stderr, "  -gha                    Write a GitHub Actions job summary and step outputs\n")
+	fmt.Fprintf(os.Stderr, "  -credits                Show release author and contributors\n")
+	fmt.Fprintf(os.Stderr, "  -raw                    Print the original, unparsed release notes\n")
+	fmt.Fprintf(os.Stderr, "  -summary                Add a line counting changes by category\n")
+	fmt.Fprintf(os.Stderr, "  -plain                  Strip inline markdown from changes in any format\n")
+	fmt.Fprintf(os.Stderr, "  -keep-md                Keep inline markdown in plain text output\n")
+	fmt.Fprintf(os.Stderr, "  -o <file>               Write to a file; the extension picks the format if none is given\n")
+	fmt.Fprintf(os.Stderr, "  -template <tmpl>        Render each entry with a Go text/template\n")
+	fmt.Fprintf(os.Stderr, "  -template-file <f>      Read the template from a file\n")
+	fmt.Fprintf(os.Stderr, "  -all                    Output every entry instead of just the latest\n")
+	fmt.Fprintf(os.Stderr, "  -n <count>              Newest N entries\n")
+	fmt.Fprintf(os.Stderr, "  -list                   List all versions\n")
+	fmt.Fprintf(os.Stderr, "  -latest, -q             Print only the latest version\n")
+	fmt.Fprintf(os.Stderr, "  -version <ver>          Get specific version\n")
+	fmt.Fprintf(os.Stderr, "  -since <ver>            Every entry newer than a version\n")
+	fmt.Fprintf(os.Stderr, "  -installed [<ver>]      Every entry newer than the installed version (detected if omitted)\n")
+	fmt.Fprintf(os.Stderr, "  -from <ver>, -to <ver>  Inclusive range of versions\n")
+	fmt.Fprintf(os.Stderr, "  -diff <from>..<to>      Everything after <from> up to <to>, as one categorized entry\n")
+	fmt.Fprintf(os.Stderr, "  -pre                    Include prereleases and drafts\n")
+	fmt.Fprintf(os.Stderr, "  -pre-only               Only prereleases and drafts\n")
+	fmt.Fprintf(os.Stderr, "  -channel <name>         stable (only stable) or preview (same as -pre-only)\n")
+	fmt.Fprintf(os.Stderr, "  -unreleased             Show the changelog's pending Unreleased section\n")
+	fmt.Fprintf(os.Stderr, "  -grep <regexp>          Only changes matching a pattern (-i to ignore case)\n")
+	fmt.Fprintf(os.Stderr, "  -watchlist-only         Only changes mentioning a watched keyword\n")
+	fmt.Fprintf(os.Stderr, "  -max-age <age>          Fail if the latest release is older than e.g. 30d, 2w\n")
+	fmt.Fprintf(os.Stderr, "  -after <date>           Only entries released on or after YYYY-MM-DD\n")
+	fmt.Fprintf(os.Stderr, "  -before <date>          Only entries released on or before YYYY-MM-DD\n")
+	fmt.Fprintf(os.Stderr, "  -web                    Open changelog source in browser\n")
+	fmt.Fprintf(os.Stderr, "  -no-cache               Don't read or write the response cache\n")
+	fmt.Fprintf(os.Stderr, "  -refresh                Re-fetch even if the cache is still fresh\n")
+	fmt.Fprintf(os.Stderr, "  -timeout <dur>          Give up on a request after e.g. 10s (default 30s)\n")
+	fmt.Fprintf(os.Stderr, "  -retries <n>            Retry transient network failures n times (default 3)\n")
+	fmt.Fprintf(os.Stderr, "  -wait                   Wait for an exhausted API rate limit to reset\n")
+	fmt.Fprintf(os.Stderr, "  -v, --version           Show aic version\n")
+	fmt.Fprintf(os.Stderr, "  -h, --help              Show this help\n\n")
 	fmt.Fprintf(os.Stderr, "Examples:\n")
 	fmt.Fprintf(os.Stderr, "  aic claude                    # Latest Claude Code entry\n")
 	fmt.Fprintf(os.Stderr, "  aic codex -json               # Latest Codex entry as JSON\n")
+	fmt.Fprintf(os.Stderr, "  aic claude -rss -all          # Full Claude Code history as RSS\n")
 	fmt.Fprintf(os.Stderr, "  aic opencode -list            # List OpenCode versions\n")
 	fmt.Fprintf(os.Stderr, "  aic gemini -version 0.21.0    # Specific Gemini version\n")
+	fmt.Fprintf(os.Stderr, "  aic claude -since 1.0.80      # Everything since 1.0.80\n")
+	fmt.Fprintf(os.Stderr, "  aic zed -list -pre-only       # List Zed preview versions\n")
 	fmt.Fprintf(os.Stderr, "  aic latest                    # All releases in last 24h\n")
 	fmt.Fprintf(os.Stderr, "  aic status                    # Status table of all tools\n")
 	fmt.Fprintf(os.Stderr, "  aic claude -web               # Open Claude changelog in browser\n")
+	fmt.Fprintf(os.Stderr, "  aic gh astral-sh/uv           # Any GitHub repo's releases\n")
+	fmt.Fprintf(os.Stderr, "  aic claude codex gemini       # Latest entry of several tools\n")
 	fmt.Fprintf(os.Stderr, "  aic status -web               # Open all changelogs in browser\n")
 }
 
 func runLatestCommand(jsonOutput bool) {
 	cutoff := time.Now().Add(-24 * time.Hour)
 
-	type result struct {
-		source  string
-		display string
-		entry   *ChangelogEntry
-		err     error
-	}
-
-	results := make(chan result, len(sources))
-	var wg sync.WaitGroup
-
-	for name, src := range sources {
-		wg.Add(1)
-		go func(name string, src Source) {
-			defer wg.Done()
-			entries, err := src.Fetch()
-			if err != nil {
-				results <- result{source: name, display: src.DisplayName, err: err}
-				return
-			}
-			if len(entries) > 0 {
-				entry := entries[0]
-				entry.Source = src.DisplayName
-				results <- result{source: name, display: src.DisplayName, entry: &entry}
-			}
-		}(name, src)
-	}
-
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
-
 	var recentEntries []ChangelogEntry
-	for r := range results {
-		if r.err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: Failed to fetch %s: %v\n", r.display, r.err)
+	for _, r := range fetchAllSources() {
+		if r.err != nil || len(r.entries) == 0 {
 			continue
 		}
-		if r.entry != nil && !r.entry.ReleasedAt.IsZero() && r.entry.ReleasedAt.After(cutoff) {
-			recentEntries = append(recentEntries, *r.entry)
+		entry := r.entries[0]
+		entry.Source = r.source.DisplayName
+		if !entry.ReleasedAt.IsZero() && entry.ReleasedAt.After(cutoff) {
+			recentEntries = append(recentEntries, entry)
 		}
 	}
 
@@ -295,61 +1367,26 @@ func runLatestCommand(jsonOutput bool) {
 }
 
 func runStatusCommand(jsonOutput bool) {
-	type statusResult struct {
-		source      string
-		displayName string
-		entries     []ChangelogEntry
-		err         error
-	}
-
-	results := make(chan statusResult, len(sources))
-	var wg sync.WaitGroup
-
-	// Fetch up to 10 entries from each source concurrently
-	for name, src := range sources {
-		wg.Add(1)
-		go func(name string, src Source) {
-			defer wg.Done()
-			entries, err := src.Fetch()
-			results <- statusResult{
-				source:      name,
-				displayName: src.DisplayName,
-				entries:     entries,
-				err:         err,
-			}
-		}(name, src)
-	}
-
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
-
 	type statusEntry struct {
-		Name            string  `json:"name"`
-		Version         string  `json:"version"`
-		PreviousVersion string  `json:"previous_version"`
-		UpdatedAgo      string  `json:"updated_ago"`
-		UpdatedRecently bool    `json:"updated_recently"`
-		AvgReleaseFreq  string  `json:"avg_release_freq"`
+		Name            string `json:"name"`
+		Version         string `json:"version"`
+		PreviousVersion string `json:"previous_version"`
+		UpdatedAgo      string `json:"updated_ago"`
+		UpdatedRecently bool   `json:"updated_recently"`
+		AvgReleaseFreq  string `json:"avg_release_freq"`
 		releasedAt      time.Time
 	}
 
 	var statusEntries []statusEntry
 	cutoff := time.Now().Add(-24 * time.Hour)
 
-	for r := range results {
-		if r.err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: Failed to fetch %s: %v\n", r.displayName, r.err)
-			continue
-		}
-
-		if len(r.entries) == 0 {
+	for _, r := range fetchAllSources() {
+		if r.err != nil || len(r.entries) == 0 {
 			continue
 		}
 
 		entry := statusEntry{
-			Name:            r.displayName,
+			Name:            r.source.DisplayName,
 			Version:         r.entries[0].Version,
 			PreviousVersion: "-",
 			UpdatedAgo:      "-",
@@ -457,6 +1494,176 @@ func runStatusCommand(jsonOutput bool) {
 		strings.Repeat("─", colFreq+2))
 }
 
+// sourceResult is the outcome of fetching one source.
+type sourceResult struct {
+	name    string
+	source  Source
+	entries []ChangelogEntry
+	err     error
+}
+
+// fetchAllSources fetches every source concurrently, returning the results
+// sorted by source name.
+func fetchAllSources() []sourceResult {
+	return fetchSources(sources)
+}
+
+// fetchSources fetches the given sources concurrently, returning the results
+// sorted by name. Sources that fail are reported in a single warning.
+func fetchSources(named map[string]Source) []sourceResult {
+	all := make([]sourceResult, 0, len(named))
+	for name, src := range named {
+		all = append(all, sourceResult{name: name, source: src})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].name < all[j].name })
+
+	forEachBounded(len(all), func(i int) {
+		all[i].entries, all[i].err = all[i].source.Fetch()
+	})
+
+	var failed []string
+	for _, r := range all {
+		if r.err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", r.source.DisplayName, r.err))
+		}
+	}
+	switch len(failed) {
+	case 0:
+	case 1:
+		fmt.Fprintf(os.Stderr, "Warning: Failed to fetch %s\n", failed[0])
+	default:
+		fmt.Fprintf(os.Stderr, "Warning: Failed to fetch %d sources:\n  %s\n", len(failed), strings.Join(failed, "\n  "))
+	}
+	return all
+}
+
+// fetchWorkers bounds how many sources are fetched at once.
+const fetchWorkers = 8
+
+// forEachBounded calls fn for 0..n-1 on up to fetchWorkers goroutines and
+// waits for them all.
+func forEachBounded(n int, fn func(i int)) {
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for range min(n, fetchWorkers) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				fn(i)
+			}
+		}()
+	}
+	for i := range n {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+}
+
+// attachNotes copies the changes of notes onto the entries with matching
+// versions, for sources whose version list and release notes come from
+// different places.
+func attachNotes(entries, notes []ChangelogEntry) {
+	byVersion := make(map[string]ChangelogEntry)
+	for _, n := range notes {
+		byVersion[n.Version] = n
+	}
+	for i := range entries {
+		if n, ok := byVersion[entries[i].Version]; ok {
+			entries[i].Sections = n.Sections
+			entries[i].Changes = n.Changes
+		}
+	}
+}
+
+func dropChanges(entries []ChangelogEntry, pattern string) ([]ChangelogEntry, error) {
+	skipRegex, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid skip pattern: %w", err)
+	}
+
+	keep := func(changes []string) []string {
+		var kept []string
+		for _, c := range changes {
+			if !skipRegex.MatchString(c) {
+				kept = append(kept, c)
+			}
+		}
+		return kept
+	}
+
+	for i := range entries {
+		entries[i].Changes = keep(entries[i].Changes)
+		var sections []Section
+		for _, section := range entries[i].Sections {
+			if section.Changes = keep(section.Changes); len(section.Changes) > 0 {
+				sections = append(sections, section)
+			}
+		}
+		entries[i].Sections = sections
+	}
+	return entries, nil
+}
+
+// filterChannel keeps either stable entries or prereleases and drafts.
+func filterChannel(entries []ChangelogEntry, preview bool) []ChangelogEntry {
+	var filtered []ChangelogEntry
+	for _, e := range entries {
+		if (e.Prerelease || e.Draft) == preview {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// filterDates keeps entries released on or after after and on or before
+// before, either of which may be zero. Undated entries are dropped.
+func filterDates(entries []ChangelogEntry, after, before time.Time) []ChangelogEntry {
+	var filtered []ChangelogEntry
+	for _, e := range entries {
+		if e.ReleasedAt.IsZero() {
+			continue
+		}
+		day := e.ReleasedAt.UTC().Truncate(24 * time.Hour)
+		if (!after.IsZero() && day.Before(after)) || (!before.IsZero() && day.After(before)) {
+			continue
+		}
+		filtered = append(filtered, e)
+	}
+	return filtered
+}
+
+// grepChanges keeps only the changes matching re, dropping sections and
+// entries left empty.
+func grepChanges(entries []ChangelogEntry, re *regexp.Regexp) []ChangelogEntry {
+	match := func(changes []string) []string {
+		var matched []string
+		for _, change := range changes {
+			if re.MatchString(change) {
+				matched = append(matched, change)
+			}
+		}
+		return matched
+	}
+
+	var filtered []ChangelogEntry
+	for _, e := range entries {
+		var sections []Section
+		for _, section := range e.Sections {
+			if changes := match(section.Changes); len(changes) > 0 {
+				sections = append(sections, Section{Name: section.Name, Category: section.Category, Changes: changes})
+			}
+		}
+		e.Sections = sections
+		e.Changes = match(e.Changes)
+		if len(e.Sections) > 0 || len(e.Changes) > 0 {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
 func truncateString(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
@@ -482,6 +1689,40 @@ func openBrowser(url string) {
 	}
 }
 
+// outputFormats maps -o file extensions to the format they imply.
+var outputFormats = map[string]string{
+	".json":  "json",
+	".jsonl": "jsonl",
+	".md":    "md",
+	".html":  "html",
+	".htm":   "html",
+	".rss":   "rss",
+	".xml":   "rss",
+	".atom":  "atom",
+	".csv":   "csv",
+	".tsv":   "tsv",
+	".adoc":  "adoc",
+	".ics":   "ical",
+}
+
+// parseAge parses an age such as "30d" or "2w", or any time.Duration.
+func parseAge(s string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if num, ok := strings.CutSuffix(s, suffix); ok {
+			n, err := strconv.Atoi(num)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid age %q", s)
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid age %q", s)
+	}
+	return d, nil
+}
+
 func formatRelativeTime(t time.Time) string {
 	if t.IsZero() {
 		return "-"
@@ -553,96 +1794,255 @@ func calculateAvgReleaseFreq(entries []ChangelogEntry) string {
 	return fmt.Sprintf("~%dmo", months)
 }
 
-func fetchGitHubReleases(owner, repo string) ([]ChangelogEntry, error) {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases", owner, repo)
+type githubRelease struct {
+	TagName     string `json:"tag_name"`
+	Name        string `json:"name"`
+	Body        string `json:"body"`
+	PublishedAt string `json:"published_at"`
+	Prerelease  bool   `json:"prerelease"`
+	Draft       bool   `json:"draft"`
+	HTMLURL     string `json:"html_url"`
+	Author      struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Reactions *Reactions `json:"reactions"`
+	Assets    []struct {
+		Name               string `json:"name"`
+		Size               int64  `json:"size"`
+		DownloadCount      int    `json:"download_count"`
+		BrowserDownloadURL string `json:"browser_download_url"`
+	} `json:"assets"`
+}
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
+func fetchGitHubReleases(owner, repo, tagPattern string, pages int) ([]ChangelogEntry, error) {
+	var tagRegex *regexp.Regexp
+	if tagPattern != "" {
+		var err error
+		if tagRegex, err = regexp.Compile(tagPattern); err != nil {
+			return nil, fmt.Errorf("invalid tag pattern: %w", err)
+		}
 	}
-	req.Header.Set("Accept", "application/vnd.github+json")
-	req.Header.Set("User-Agent", "aic-changelog")
 
-	resp, err := http.DefaultClient.Do(req)
+	releases, err := fetchGitHubReleaseList(owner, repo, pages)
 	if err != nil {
-		return nil, fmt.Errorf("HTTP request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
-	}
-
-	var releases []struct {
-		TagName     string `json:"tag_name"`
-		Name        string `json:"name"`
-		Body        string `json:"body"`
-		PublishedAt string `json:"published_at"`
-	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
-		return nil, fmt.Errorf("failed to parse releases: %w", err)
+		return nil, err
 	}
 
 	var entries []ChangelogEntry
 	for _, rel := range releases {
 		ver := rel.TagName
+		if tagRegex != nil {
+			var ok bool
+			if ver, ok = matchVersion(tagRegex, ver); !ok {
+				continue
+			}
+		}
 		ver = strings.TrimPrefix(ver, "v")
 		ver = strings.TrimPrefix(ver, "rust-v")
 
 		sections, ungroupedChanges := parseReleaseBody(rel.Body)
+		contributors, newContributors := parseCredits(rel.Body)
+
+		var downloads int
+		var assets []Asset
+		for _, asset := range rel.Assets {
+			downloads += asset.DownloadCount
+			assets = append(assets, Asset{Name: asset.Name, Size: asset.Size, URL: asset.BrowserDownloadURL})
+		}
 
 		releasedAt, _ := time.Parse(time.RFC3339, rel.PublishedAt)
 
 		entries = append(entries, ChangelogEntry{
 			Version:    ver,
 			ReleasedAt: releasedAt,
+			Prerelease: rel.Prerelease,
+			Draft:      rel.Draft,
+			URL:        rel.HTMLURL,
 			Sections:   sections,
 			Changes:    ungroupedChanges,
+
+			Author:          rel.Author.Login,
+			Contributors:    contributors,
+			NewContributors: newContributors,
+
+			Reactions: rel.Reactions,
+			Downloads: downloads,
+			Assets:    assets,
+
+			raw: rel.Body,
 		})
 	}
 
+	linkGitHubRefs(entries, owner, repo)
 	return entries, nil
 }
 
+// fetchGitHubReleaseList fetches up to pages pages of 100 releases (at
+// least one), newest first.
+func fetchGitHubReleaseList(owner, repo string, pages int) ([]githubRelease, error) {
+	headers := map[string]string{"Accept": "application/vnd.github+json"}
+
+	var releases []githubRelease
+	for page := 1; page <= max(pages, 1); page++ {
+		url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases?per_page=100&page=%d", owner, repo, page)
+		body, err := fetchURLWithHeaders(url, headers)
+		if err != nil {
+			return nil, err
+		}
+
+		var pageReleases []githubRelease
+		if err := json.Unmarshal(body, &pageReleases); err != nil {
+			return nil, fmt.Errorf("failed to parse releases: %w", err)
+		}
+
+		releases = append(releases, pageReleases...)
+		if len(pageReleases) < 100 {
+			break
+		}
+	}
+
+	return releases, nil
+}
+
+// GitHub's generated release notes end each line with "by @user in <PR URL>"
+var pullRequestSuffixRegex = regexp.MustCompile(`\s+by @[\w-]+(?:\[bot\])? in https://github\.com/[\w.-]+/[\w.-]+/pull/(\d+)$`)
+
+// GoReleaser's generated changelogs start each line with "<sha>: "
+var commitPrefixRegex = regexp.MustCompile(`^[0-9a-f]{7,40}: `)
+
+var orderedItemRegex = regexp.MustCompile(`^\d+\.\s+`)
+
+var (
+	creditRegex          = regexp.MustCompile(`by @([\w-]+(?:\[bot\])?) in https://github\.com/`)
+	firstContributeRegex = regexp.MustCompile(`^[-*]\s+@([\w-]+(?:\[bot\])?) made their first contribution`)
+)
+
+// parseCredits collects the users credited in GitHub's generated release
+// notes ("... by @user in <PR URL>") and its "New Contributors" list.
+func parseCredits(body string) (contributors, newContributors []string) {
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		if m := firstContributeRegex.FindStringSubmatch(line); m != nil {
+			newContributors = append(newContributors, m[1])
+			continue
+		}
+		if m := creditRegex.FindStringSubmatch(line); m != nil && !seen[m[1]] {
+			seen[m[1]] = true
+			contributors = append(contributors, m[1])
+		}
+	}
+	return contributors, newContributors
+}
+
+// Some release notes use a bold line rather than a heading for subsections
+var boldHeadingRegex = regexp.MustCompile(`^\*\*([^*]+?):?\*\*:?$`)
+
+// sectionCategories normalize section names ("🐛 Bug Fixes", "New Features",
+// "Breaking Changes", ...) to a category, checked in order.
+var sectionCategories = []struct {
+	category string
+	re       *regexp.Regexp
+}{
+	{"breaking", regexp.MustCompile(`(?i)breaking`)},
+	{"security", regexp.MustCompile(`(?i)secur`)},
+	{"deprecated", regexp.MustCompile(`(?i)deprecat`)},
+	{"removed", regexp.MustCompile(`(?i)remov|delet|drop`)},
+	{"fixed", regexp.MustCompile(`(?i)fix|bug`)},
+	{"added", regexp.MustCompile(`(?i)add|new|feat|enhance`)},
+	{"changed", regexp.MustCompile(`(?i)chang|improv|updat|perf|refactor`)},
+	{"docs", regexp.MustCompile(`(?i)doc`)},
+}
+
+// sectionCategory returns the category of a section name, or "" if it isn't
+// recognized.
+func sectionCategory(name string) string {
+	for _, c := range sectionCategories {
+		if c.re.MatchString(name) {
+			return c.category
+		}
+	}
+	return ""
+}
+
 func parseReleaseBody(body string) ([]Section, []string) {
 	var sections []Section
 	var ungroupedChanges []string
 
-	headerRegex := regexp.MustCompile(`^#{1,3}\s+(.+)$`)
 	lines := strings.Split(body, "\n")
 
 	var currentSection *Section
 
+	// Sub-bullets and indented continuation lines are folded into the
+	// top-level change above them, keeping sub-bullets on their own
+	// indented lines. last is that change, nil when there isn't one.
+	var last *string
+	var indents []int
+
 	for _, line := range lines {
 		trimmed := strings.TrimSpace(line)
+		expanded := strings.ReplaceAll(line, "\t", "    ")
+		indent := len(expanded) - len(strings.TrimLeft(expanded, " "))
 
-		// Check for section header (# ## or ###)
-		if match := headerRegex.FindStringSubmatch(trimmed); match != nil {
+		// Check for section header (# to ######, or a bold line)
+		match := headingRegex.FindStringSubmatch(trimmed)
+		if match == nil {
+			match = boldHeadingRegex.FindStringSubmatch(trimmed)
+		}
+		if match != nil {
+			last = nil
 			headerName := strings.TrimSpace(match[1])
-			// Skip "What's Changed" as it's just a wrapper, not a real category
-			if headerName == "What's Changed" {
+			// Skip "What's Changed" and GoReleaser's "Changelog" as they're
+			// just wrappers, not real categories
+			if headerName == "What's Changed" || headerName == "Changelog" {
 				continue
 			}
 			// Save previous section if exists
 			if currentSection != nil && len(currentSection.Changes) > 0 {
 				sections = append(sections, *currentSection)
 			}
-			currentSection = &Section{Name: headerName}
+			currentSection = &Section{Name: headerName, Category: sectionCategory(headerName)}
 			continue
 		}
 
-		// Check for list item
-		if strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* ") {
+		// Check for list item (- item, * item or 1. item)
+		if strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* ") || orderedItemRegex.MatchString(trimmed) {
 			change := strings.TrimPrefix(trimmed, "- ")
 			change = strings.TrimPrefix(change, "* ")
+			change = orderedItemRegex.ReplaceAllString(change, "")
+			change = pullRequestSuffixRegex.ReplaceAllString(change, " (#$1)")
+			change = commitPrefixRegex.ReplaceAllString(change, "")
+
+			if indent >= 2 && last != nil {
+				for len(indents) > 0 && indent <= indents[len(indents)-1] {
+					indents = indents[:len(indents)-1]
+				}
+				indents = append(indents, indent)
+				if change != "" {
+					*last += "\n" + strings.Repeat("  ", len(indents)) + "- " + change
+				}
+				continue
+			}
+
+			last, indents = nil, nil
 			if change != "" && !strings.HasPrefix(change, "@") {
+				changes := &ungroupedChanges
 				if currentSection != nil {
-					currentSection.Changes = append(currentSection.Changes, change)
-				} else {
-					ungroupedChanges = append(ungroupedChanges, change)
+					changes = &currentSection.Changes
 				}
+				*changes = append(*changes, change)
+				last = &(*changes)[len(*changes)-1]
 			}
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+		case indent >= 2 && last != nil:
+			// Continuation of the previous item's text
+			*last += " " + trimmed
+		default:
+			last = nil
 		}
 	}
 
@@ -655,49 +2055,96 @@ func parseReleaseBody(body string) ([]Section, []string) {
 }
 
 func outputJSON(entry *ChangelogEntry) {
+	writeJSON(entry)
+}
+
+// outputJSONLines writes each entry as a compact JSON object on its own line,
+// flushing as it goes so pipelines can stream the output.
+func outputJSONLines(displayName string, entries []ChangelogEntry) {
 	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(entry); err != nil {
-		fmt.Fprintf(os.Stderr, "Error encoding JSON: %v\n", err)
-		os.Exit(1)
+	encoder.SetEscapeHTML(false)
+	for _, entry := range entries {
+		entry.Source = displayName
+		if err := encoder.Encode(entry); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding JSON: %v\n", err)
+			os.Exit(1)
+		}
 	}
 }
 
 func outputMarkdown(entry *ChangelogEntry) {
+	writeMarkdown(os.Stdout, entry)
+}
+
+func writeMarkdown(w io.Writer, entry *ChangelogEntry) {
 	if !entry.ReleasedAt.IsZero() {
-		fmt.Printf("## %s (%s)\n\n", entry.Version, entry.ReleasedAt.Format("2006-01-02"))
+		fmt.Fprintf(w, "## %s (%s)\n\n", entry.Version, entry.ReleasedAt.Format("2006-01-02"))
 	} else {
-		fmt.Printf("## %s\n\n", entry.Version)
+		fmt.Fprintf(w, "## %s\n\n", entry.Version)
 	}
 
 	// Output sectioned changes
 	for _, section := range entry.Sections {
-		fmt.Printf("### %s\n\n", section.Name)
+		fmt.Fprintf(w, "### %s\n\n", section.Name)
 		for _, change := range section.Changes {
-			fmt.Printf("- %s\n", change)
+			fmt.Fprintf(w, "- %s\n", change)
 		}
-		fmt.Println()
+		fmt.Fprintln(w)
 	}
 
 	// Output ungrouped changes
 	for _, change := range entry.Changes {
-		fmt.Printf("- %s\n", change)
+		fmt.Fprintf(w, "- %s\n", change)
+	}
+
+	if entry.URL != "" {
+		// Sections already end with a blank line
+		if len(entry.Changes) > 0 || len(entry.Sections) == 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "[Full release notes](%s)\n", entry.URL)
+	}
+}
+
+// outputCredits lists who published and contributed to each entry.
+func outputCredits(displayName string, entries []ChangelogEntry) {
+	for i, entry := range entries {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%s %s\n", displayName, entry.Version)
+		if entry.Author == "" && len(entry.Contributors) == 0 && len(entry.NewContributors) == 0 {
+			fmt.Println("  No credits available")
+			continue
+		}
+		if entry.Author != "" {
+			fmt.Printf("  Released by:      @%s\n", entry.Author)
+		}
+		if len(entry.Contributors) > 0 {
+			fmt.Printf("  Contributors:     @%s\n", strings.Join(entry.Contributors, ", @"))
+		}
+		if len(entry.NewContributors) > 0 {
+			fmt.Printf("  New contributors: @%s\n", strings.Join(entry.NewContributors, ", @"))
+		}
 	}
 }
 
 func outputPlainText(displayName string, entry *ChangelogEntry) {
 	if !entry.ReleasedAt.IsZero() {
-		fmt.Printf("%s %s (%s)\n", displayName, entry.Version, entry.ReleasedAt.Format("2006-01-02"))
+		fmt.Printf("%s %s (%s, released %s)\n", displayName, entry.Version, entry.ReleasedAt.Format("2006-01-02"), formatRelativeTime(entry.ReleasedAt))
 	} else {
 		fmt.Printf("%s %s\n", displayName, entry.Version)
 	}
 	fmt.Println(strings.Repeat("-", 40))
+	if entry.Metrics != nil {
+		fmt.Println(entry.Metrics)
+	}
 
 	// Output sectioned changes
 	for _, section := range entry.Sections {
 		fmt.Printf("\n[%s]\n", section.Name)
 		for _, change := range section.Changes {
-			fmt.Printf("  * %s\n", change)
+			fmt.Printf("  * %s\n", strings.ReplaceAll(change, "\n", "\n  "))
 		}
 	}
 
@@ -706,6 +2153,69 @@ func outputPlainText(displayName string, entry *ChangelogEntry) {
 		fmt.Println()
 	}
 	for _, change := range entry.Changes {
-		fmt.Printf("  * %s\n", change)
+		fmt.Printf("  * %s\n", strings.ReplaceAll(change, "\n", "\n  "))
+	}
+
+	if entry.URL != "" {
+		fmt.Printf("\n%s\n", entry.URL)
+	}
+}
+
+// mergeDuplicates merges entries whose versions are the same once
+// normalized (e.g. "v1.2.0" and "1.2.0" from differently prefixed tags),
+// keeping the first entry's details and combining their changes. Repeated
+// changes within an entry are dropped.
+func mergeDuplicates(entries []ChangelogEntry) []ChangelogEntry {
+	var merged []ChangelogEntry
+	index := make(map[string]int)
+	for _, e := range entries {
+		key := normalizeVersion(e.Version)
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(merged)
+			merged = append(merged, e)
+			continue
+		}
+
+		m := &merged[i]
+	sections:
+		for _, section := range e.Sections {
+			for j := range m.Sections {
+				if strings.EqualFold(m.Sections[j].Name, section.Name) {
+					m.Sections[j].Changes = append(m.Sections[j].Changes, section.Changes...)
+					continue sections
+				}
+			}
+			m.Sections = append(m.Sections, section)
+		}
+		m.Changes = append(m.Changes, e.Changes...)
+		m.Downloads += e.Downloads
+		if m.ReleasedAt.IsZero() {
+			m.ReleasedAt = e.ReleasedAt
+		}
+	}
+
+	for i := range merged {
+		seen := make(map[string]bool)
+		unique := func(changes []string) []string {
+			var kept []string
+			for _, c := range changes {
+				if key := strings.TrimSpace(c); !seen[key] {
+					seen[key] = true
+					kept = append(kept, c)
+				}
+			}
+			return kept
+		}
+
+		var sections []Section
+		for _, section := range merged[i].Sections {
+			if section.Changes = unique(section.Changes); len(section.Changes) > 0 {
+				sections = append(sections, section)
+			}
+		}
+		merged[i].Sections = sections
+		merged[i].Changes = unique(merged[i].Changes)
 	}
+	return merged
 }
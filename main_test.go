@@ -0,0 +1,110 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseReleaseBody(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		sections  []Section
+		ungrouped []string
+	}{
+		{
+			name:      "flat list",
+			body:      "- Added foo\n* Fixed bar",
+			ungrouped: []string{"Added foo", "Fixed bar"},
+		},
+		{
+			name:      "generated release notes",
+			body:      "- Fix it by @someone in https://github.com/o/r/pull/12\n- @someone made their first contribution",
+			ungrouped: []string{"Fix it (#12)"},
+		},
+		{
+			name:      "ordered list",
+			body:      "1. First\n2. Second",
+			ungrouped: []string{"First", "Second"},
+		},
+		{
+			name:      "GoReleaser changelog",
+			body:      "## Changelog\n- 1a2b3c4: Tidy up",
+			ungrouped: []string{"Tidy up"},
+		},
+		{
+			name: "sections",
+			body: "## What's Changed\n### Features\n- New thing\n\n**Bug Fixes**\n- Fix crash",
+			sections: []Section{
+				{Name: "Features", Category: "added", Changes: []string{"New thing"}},
+				{Name: "Bug Fixes", Category: "fixed", Changes: []string{"Fix crash"}},
+			},
+		},
+		{
+			name:      "nested bullets",
+			body:      "- Parent\n  - Child\n    - Grandchild\n  - Second child\n- Next",
+			ungrouped: []string{"Parent\n  - Child\n    - Grandchild\n  - Second child", "Next"},
+		},
+		{
+			name:      "tab-indented bullets",
+			body:      "- Parent\n\t- Child",
+			ungrouped: []string{"Parent\n  - Child"},
+		},
+		{
+			name:      "continuation lines",
+			body:      "- Wrapped\n  onto two lines\nNot part of it\n  nor this",
+			ungrouped: []string{"Wrapped onto two lines"},
+		},
+		{
+			name:      "indented bullet without a parent",
+			body:      "Intro\n  - Orphan",
+			ungrouped: []string{"Orphan"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sections, ungrouped := parseReleaseBody(tt.body)
+			if !reflect.DeepEqual(sections, tt.sections) {
+				t.Errorf("sections = %#v, want %#v", sections, tt.sections)
+			}
+			if !reflect.DeepEqual(ungrouped, tt.ungrouped) {
+				t.Errorf("ungrouped = %#v, want %#v", ungrouped, tt.ungrouped)
+			}
+		})
+	}
+}
+
+func TestMergeDuplicates(t *testing.T) {
+	entries := []ChangelogEntry{
+		{
+			Version:   "v1.2.0",
+			Sections:  []Section{{Name: "Fixed", Changes: []string{"Fix a", "Fix b"}}},
+			Changes:   []string{"Note"},
+			Downloads: 3,
+		},
+		{Version: "1.1.0", Changes: []string{"Older"}},
+		{
+			Version:   "1.2",
+			Sections:  []Section{{Name: "fixed", Changes: []string{"Fix b", "Fix c"}}, {Name: "Added", Changes: []string{"Add d"}}},
+			Changes:   []string{" Note"},
+			Downloads: 4,
+		},
+	}
+
+	want := []ChangelogEntry{
+		{
+			Version: "v1.2.0",
+			Sections: []Section{
+				{Name: "Fixed", Changes: []string{"Fix a", "Fix b", "Fix c"}},
+				{Name: "Added", Changes: []string{"Add d"}},
+			},
+			Changes:   []string{"Note"},
+			Downloads: 7,
+		},
+		{Version: "1.1.0", Changes: []string{"Older"}},
+	}
+	if got := mergeDuplicates(entries); !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeDuplicates() = %#v, want %#v", got, want)
+	}
+}
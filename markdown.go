@@ -0,0 +1,262 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	mdImageRegex  = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdItalicRegex = regexp.MustCompile(`(^|\W)\*([^*\s][^*]*?)\*(\W|$)`)
+)
+
+var (
+	headingRegex    = regexp.MustCompile(`^#{1,6}\s+(.+)$`)
+	unreleasedRegex = regexp.MustCompile(`(?i)^\[?(unreleased|upcoming|pending)\b`)
+	dateRegex       = regexp.MustCompile(`\d{4}[-./]\d{2}[-./]\d{2}|(?i:jan|feb|mar|apr|may|jun|jul|aug|sep|oct|nov|dec)[a-z]*\.?\s+\d{1,2}(?:st|nd|rd|th)?,?\s+\d{4}`)
+)
+
+// defaultVersionPattern matches the common "## 1.2.3" and "## [1.2.3]"
+// changelog heading styles.
+const defaultVersionPattern = `^\[?v?(\d+\.\d+\.\d+[\w.+-]*)\]?`
+
+var dateLayouts = []string{
+	"2006-01-02",
+	"January 2, 2006",
+	"January 2 2006",
+	"Jan 2, 2006",
+	"Jan 2 2006",
+}
+
+func fetchGitHubChangelog(owner, repo, path, versionPattern string) ([]ChangelogEntry, error) {
+	url := fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/HEAD/%s", owner, repo, path)
+	entries, err := fetchMarkdownChangelog(url, versionPattern)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		entries[i].URL = fmt.Sprintf("https://github.com/%s/%s/blob/HEAD/%s#%s", owner, repo, path, githubAnchor(entries[i].heading))
+	}
+	linkGitHubRefs(entries, owner, repo)
+	return entries, nil
+}
+
+// githubAnchor returns the fragment GitHub generates for a markdown heading:
+// lowercased, punctuation dropped and spaces turned into hyphens.
+func githubAnchor(heading string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case r == ' ':
+			b.WriteRune('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func fetchMarkdownChangelog(url, versionPattern string) ([]ChangelogEntry, error) {
+	body, err := fetchURL(url)
+	if err != nil {
+		return nil, err
+	}
+	return parseMarkdownChangelog(string(body), versionPattern)
+}
+
+// parseMarkdownChangelog splits a markdown changelog into entries at every
+// heading matching versionPattern. With an empty pattern, entries are split at
+// dated headings instead and versioned by their date, for changelogs that
+// don't use version numbers. An "Unreleased" heading starts an entry marked
+// Unreleased. Content before the first entry is ignored.
+func parseMarkdownChangelog(body, versionPattern string) ([]ChangelogEntry, error) {
+	var versionRegex *regexp.Regexp
+	if versionPattern != "" {
+		var err error
+		if versionRegex, err = regexp.Compile(versionPattern); err != nil {
+			return nil, fmt.Errorf("invalid version pattern: %w", err)
+		}
+	}
+
+	var entries []ChangelogEntry
+	var current *ChangelogEntry
+	var bodyLines []string
+	seenDates := make(map[string]int)
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		current.raw = strings.TrimSpace(strings.Join(bodyLines, "\n"))
+		sections, changes := parseReleaseBody(current.raw)
+		current.Sections = sections
+		current.Changes = append(current.Changes, changes...)
+		entries = append(entries, *current)
+	}
+
+	lines := strings.Split(body, "\n")
+	for i := 0; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+
+		if match := headingRegex.FindStringSubmatch(trimmed); match != nil {
+			heading := strings.TrimSpace(match[1])
+			if unreleasedRegex.MatchString(heading) {
+				flush()
+				current = &ChangelogEntry{Version: "Unreleased", Unreleased: true, heading: heading}
+				bodyLines = nil
+				continue
+			}
+			if versionRegex != nil {
+				if ver, ok := matchVersion(versionRegex, heading); ok {
+					flush()
+					current = &ChangelogEntry{Version: ver, heading: heading}
+					current.ReleasedAt, i = headingDate(lines, i)
+					bodyLines = nil
+					continue
+				}
+			} else if date, next := headingDate(lines, i); !date.IsZero() {
+				flush()
+				current = &ChangelogEntry{Version: datedVersion(date, seenDates), ReleasedAt: date, heading: heading}
+				if title := strings.Trim(dateRegex.ReplaceAllString(heading, ""), " -–—:|()"); title != "" {
+					current.Changes = []string{title}
+				}
+				bodyLines = nil
+				i = next
+				continue
+			}
+		}
+
+		if current != nil {
+			bodyLines = append(bodyLines, lines[i])
+		}
+	}
+	flush()
+
+	return entries, nil
+}
+
+// headingDate returns the date of the heading at lines[i]. Some changelogs
+// put the date on its own line under the heading instead; in that case the
+// returned index points at that line so the caller can skip it.
+func headingDate(lines []string, i int) (time.Time, int) {
+	if date := parseDate(lines[i]); !date.IsZero() {
+		return date, i
+	}
+	for j := i + 1; j < len(lines); j++ {
+		next := strings.TrimSpace(lines[j])
+		if next == "" {
+			continue
+		}
+		if len(next) < 40 && !headingRegex.MatchString(next) {
+			if date := parseDate(next); !date.IsZero() {
+				return date, j
+			}
+		}
+		break
+	}
+	return time.Time{}, i
+}
+
+// datedVersion synthesizes a version identifier for an unversioned entry
+// from its date, suffixing repeated dates (2025-01-02, 2025-01-02.2, ...).
+func datedVersion(date time.Time, seen map[string]int) string {
+	ver := date.Format("2006-01-02")
+	seen[ver]++
+	if n := seen[ver]; n > 1 {
+		return fmt.Sprintf("%s.%d", ver, n)
+	}
+	return ver
+}
+
+// matchVersion returns the first capture group of re in s, or the whole
+// match when re has no groups.
+func matchVersion(re *regexp.Regexp, s string) (string, bool) {
+	m := re.FindStringSubmatch(s)
+	if m == nil {
+		return "", false
+	}
+	if len(m) > 1 && m[1] != "" {
+		return m[1], true
+	}
+	return m[0], true
+}
+
+// parseDate extracts the first recognizable date from s, returning the zero
+// time if none is found.
+func parseDate(s string) time.Time {
+	match := dateRegex.FindString(s)
+	if match == "" {
+		return time.Time{}
+	}
+	if match[0] >= '0' && match[0] <= '9' {
+		match = strings.NewReplacer(".", "-", "/", "-").Replace(match)
+	}
+	match = strings.Join(strings.Fields(match), " ")
+	match = strings.Replace(match, ".", "", 1)
+	for _, suffix := range []string{"st,", "nd,", "rd,", "th,"} {
+		match = strings.Replace(match, suffix, ",", 1)
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, match); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// stripMarkdown reduces inline markdown to plain text: links and images to
+// their text, and bold, italic and code spans to their contents.
+func stripMarkdown(s string) string {
+	s = mdImageRegex.ReplaceAllString(s, "$1")
+	s = mdCodeRegex.ReplaceAllString(s, "$1")
+	s = mdLinkRegex.ReplaceAllString(s, "$1")
+	s = mdBoldRegex.ReplaceAllString(s, "$1$2")
+	s = mdItalicRegex.ReplaceAllString(s, "$1$2$3")
+	return s
+}
+
+// stripEntriesMarkdown returns copies of entries with stripMarkdown applied
+// to every change.
+func stripEntriesMarkdown(entries []ChangelogEntry) []ChangelogEntry {
+	strip := func(changes []string) []string {
+		stripped := make([]string, len(changes))
+		for i, change := range changes {
+			stripped[i] = stripMarkdown(change)
+		}
+		return stripped
+	}
+
+	stripped := make([]ChangelogEntry, len(entries))
+	for i, e := range entries {
+		sections := make([]Section, len(e.Sections))
+		for j, section := range e.Sections {
+			section.Changes = strip(section.Changes)
+			sections[j] = section
+		}
+		e.Sections = sections
+		e.Changes = strip(e.Changes)
+		stripped[i] = e
+	}
+	return stripped
+}
+
+// addPlainChanges returns copies of entries with PlainChanges set next to
+// every list of changes, so JSON consumers get both forms.
+func addPlainChanges(entries []ChangelogEntry) []ChangelogEntry {
+	stripped := stripEntriesMarkdown(entries)
+	withPlain := make([]ChangelogEntry, len(entries))
+	for i, e := range entries {
+		sections := make([]Section, len(e.Sections))
+		for j, section := range e.Sections {
+			section.PlainChanges = stripped[i].Sections[j].Changes
+			sections[j] = section
+		}
+		e.Sections = sections
+		e.PlainChanges = stripped[i].Changes
+		withPlain[i] = e
+	}
+	return withPlain
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+	tests := map[string]time.Time{
+		"## 1.2.3 - 2025-01-02":      day(2025, 1, 2),
+		"2025/01/02":                 day(2025, 1, 2),
+		"Released 2025.01.02":        day(2025, 1, 2),
+		"January 2, 2025":            day(2025, 1, 2),
+		"Jan. 2nd, 2025":             day(2025, 1, 2),
+		"Sep 30 2025":                day(2025, 9, 30),
+		"## 1.2.3":                   {},
+		"2025-13-45 isn't a date":    {},
+		"see https://example.com/42": {},
+	}
+	for in, want := range tests {
+		if got := parseDate(in); !got.Equal(want) {
+			t.Errorf("parseDate(%q) = %v, want %v", in, got, want)
+		}
+	}
+}
+
+func TestParseMarkdownChangelog(t *testing.T) {
+	body := `# Changelog
+
+Intro text is ignored.
+
+## [Unreleased]
+- Upcoming
+
+## [1.2.0] - 2025-01-02
+### Added
+- New flag
+### Fixed
+- Crash
+
+## 1.1.0
+2024-12-20
+
+- Older change
+`
+	entries, err := parseMarkdownChangelog(body, defaultVersionPattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		version    string
+		releasedAt time.Time
+		unreleased bool
+		sections   int
+		changes    []string
+	}{
+		{"Unreleased", time.Time{}, true, 0, []string{"Upcoming"}},
+		{"1.2.0", time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), false, 2, nil},
+		{"1.1.0", time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC), false, 0, []string{"Older change"}},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(entries), len(want))
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Version != w.version || !e.ReleasedAt.Equal(w.releasedAt) || e.Unreleased != w.unreleased || len(e.Sections) != w.sections || !reflect.DeepEqual(e.Changes, w.changes) {
+			t.Errorf("entry %d = %s %v unreleased=%v sections=%d changes=%q, want %s %v unreleased=%v sections=%d changes=%q",
+				i, e.Version, e.ReleasedAt, e.Unreleased, len(e.Sections), e.Changes, w.version, w.releasedAt, w.unreleased, w.sections, w.changes)
+		}
+	}
+}
+
+func TestParseMarkdownChangelogDated(t *testing.T) {
+	body := "## January 2, 2025 - Faster search\n- One\n\n## 2025-01-02\n- Two\n\n## Not dated\n- Folded into the entry above\n"
+	entries, err := parseMarkdownChangelog(body, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.Version)
+	}
+	if want := []string{"2025-01-02", "2025-01-02.2"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("versions = %v, want %v", got, want)
+	}
+	if got, want := entries[0].Changes, []string{"Faster search", "One"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("first entry changes = %q, want %q", got, want)
+	}
+}
+
+func TestStripMarkdown(t *testing.T) {
+	tests := map[string]string{
+		"**Bold** and *italic*":                 "Bold and italic",
+		"Use `aic -json`":                       "Use aic -json",
+		"See [the docs](https://example.com)":   "See the docs",
+		"![logo](https://example.com/logo.png)": "logo",
+		"snake_case and 2*3*4 stay":             "snake_case and 2*3*4 stay",
+	}
+	for in, want := range tests {
+		if got := stripMarkdown(in); got != want {
+			t.Errorf("stripMarkdown(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
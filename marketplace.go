@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+func jetbrainsPlugin(pluginID string) func() ([]ChangelogEntry, error) {
+	return func() ([]ChangelogEntry, error) { return fetchJetBrainsPlugin(pluginID) }
+}
+
+func fetchJetBrainsPlugin(pluginID string) ([]ChangelogEntry, error) {
+	url := fmt.Sprintf("https://plugins.jetbrains.com/api/plugins/%s/updates?size=100", pluginID)
+	body, err := fetchURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var updates []struct {
+		Version string `json:"version"`
+		Channel string `json:"channel"`
+		Notes   string `json:"notes"`
+		CDate   any    `json:"cdate"`
+	}
+	if err := json.Unmarshal(body, &updates); err != nil {
+		return nil, fmt.Errorf("failed to parse plugin updates: %w", err)
+	}
+
+	var entries []ChangelogEntry
+	for _, u := range updates {
+		sections, changes := parseReleaseBody(htmlToMarkdown(u.Notes))
+		entries = append(entries, ChangelogEntry{
+			Version:    u.Version,
+			ReleasedAt: parseMillis(u.CDate),
+			Prerelease: u.Channel != "",
+			Sections:   sections,
+			Changes:    changes,
+			raw:        u.Notes,
+		})
+	}
+
+	return entries, nil
+}
+
+// parseMillis converts a Unix millisecond timestamp, which the JetBrains API
+// returns either as a number or a string, into a time.
+func parseMillis(v any) time.Time {
+	var ms int64
+	switch v := v.(type) {
+	case float64:
+		ms = int64(v)
+	case string:
+		ms, _ = strconv.ParseInt(v, 10, 64)
+	}
+	if ms == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(ms).UTC()
+}
+
+const vscodeChangelogAsset = "Microsoft.VisualStudio.Services.Content.Changelog"
+
+func vsixSource(itemName string) (Source, error) {
+	publisher, name, ok := strings.Cut(itemName, ".")
+	if !ok || publisher == "" || name == "" {
+		return Source{}, fmt.Errorf("invalid extension '%s' (expected publisher.extension)", itemName)
+	}
+	return Source{
+		DisplayName:  itemName,
+		ChangelogURL: "https://marketplace.visualstudio.com/items/" + itemName + "/changelog",
+		FetchFunc:    vscodeExtension(itemName),
+	}, nil
+}
+
+func vscodeExtension(itemName string) func() ([]ChangelogEntry, error) {
+	return func() ([]ChangelogEntry, error) { return fetchVSCodeExtension(itemName) }
+}
+
+// fetchVSCodeExtension lists an extension's versions from the VS Code
+// Marketplace gallery API and fills in notes from the CHANGELOG.md shipped
+// with the newest version.
+func fetchVSCodeExtension(itemName string) ([]ChangelogEntry, error) {
+	query := map[string]any{
+		"filters": []any{map[string]any{
+			"criteria": []any{map[string]any{"filterType": 7, "value": itemName}},
+		}},
+		// IncludeVersions | IncludeFiles | IncludeVersionProperties
+		"flags": 0x1 | 0x2 | 0x10,
+	}
+	payload, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := postURL("https://marketplace.visualstudio.com/_apis/public/gallery/extensionquery", map[string]string{
+		"Accept":       "application/json;api-version=3.0-preview.1",
+		"Content-Type": "application/json",
+	}, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Results []struct {
+			Extensions []struct {
+				Versions []struct {
+					Version     string `json:"version"`
+					LastUpdated string `json:"lastUpdated"`
+					Files       []struct {
+						AssetType string `json:"assetType"`
+						Source    string `json:"source"`
+					} `json:"files"`
+					Properties []struct {
+						Key   string `json:"key"`
+						Value string `json:"value"`
+					} `json:"properties"`
+				} `json:"versions"`
+			} `json:"extensions"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse marketplace response: %w", err)
+	}
+	if len(result.Results) == 0 || len(result.Results[0].Extensions) == 0 {
+		return nil, fmt.Errorf("extension %s not found", itemName)
+	}
+	versions := result.Results[0].Extensions[0].Versions
+
+	var entries []ChangelogEntry
+	var changelogURL string
+	seen := make(map[string]bool)
+	for _, v := range versions {
+		// Platform-specific builds are listed once per target
+		if seen[v.Version] {
+			continue
+		}
+		seen[v.Version] = true
+
+		entry := ChangelogEntry{Version: v.Version}
+		entry.ReleasedAt, _ = time.Parse(time.RFC3339, v.LastUpdated)
+		for _, p := range v.Properties {
+			if p.Key == "Microsoft.VisualStudio.Code.PreRelease" && p.Value == "true" {
+				entry.Prerelease = true
+			}
+		}
+		if changelogURL == "" {
+			for _, f := range v.Files {
+				if f.AssetType == vscodeChangelogAsset {
+					changelogURL = f.Source
+				}
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	if changelogURL == "" {
+		return entries, nil
+	}
+	body, err = fetchURL(changelogURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch changelog: %w", err)
+	}
+	notes, err := parseMarkdownChangelog(string(body), defaultVersionPattern)
+	if err != nil {
+		return nil, err
+	}
+	attachNotes(entries, notes)
+
+	return entries, nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// fetchMCPSpecChangelog collects the per-revision changelogs of the Model
+// Context Protocol specification. Each revision lives in a date-named
+// directory under docs/specification; the draft revision is skipped.
+func fetchMCPSpecChangelog() ([]ChangelogEntry, error) {
+	const owner, repo, dir = "modelcontextprotocol", "modelcontextprotocol", "docs/specification"
+
+	body, err := fetchURL(fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s", owner, repo, dir))
+	if err != nil {
+		return nil, err
+	}
+
+	var contents []struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(body, &contents); err != nil {
+		return nil, fmt.Errorf("failed to parse repository contents: %w", err)
+	}
+
+	var revisions []string
+	for _, c := range contents {
+		if c.Type != "dir" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", c.Name); err == nil {
+			revisions = append(revisions, c.Name)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(revisions)))
+
+	var entries []ChangelogEntry
+	for _, rev := range revisions {
+		url := fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/HEAD/%s/%s/changelog.mdx", owner, repo, dir, rev)
+		doc, err := fetchURL(url)
+		if err != nil {
+			// The initial revision has no changelog
+			var statusErr *httpStatusError
+			if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return nil, err
+		}
+
+		releasedAt, _ := time.Parse("2006-01-02", rev)
+		raw := stripFrontMatter(string(doc))
+		sections, changes := parseReleaseBody(raw)
+		entries = append(entries, ChangelogEntry{
+			Version:    rev,
+			ReleasedAt: releasedAt,
+			Sections:   sections,
+			Changes:    changes,
+			raw:        raw,
+		})
+	}
+
+	return entries, nil
+}
+
+// stripFrontMatter removes a leading "---" delimited metadata block.
+func stripFrontMatter(doc string) string {
+	if !strings.HasPrefix(doc, "---") {
+		return doc
+	}
+	rest := doc[3:]
+	if end := strings.Index(rest, "\n---"); end >= 0 {
+		return rest[end+4:]
+	}
+	return doc
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Metrics gauge how big a release is.
+type Metrics struct {
+	Changes    int            `json:"changes"`
+	Breaking   int            `json:"breaking"`
+	ByCategory map[string]int `json:"by_category"`
+}
+
+// changeCount is the number of changes in an entry, sectioned or not.
+func (e *ChangelogEntry) changeCount() int {
+	n := len(e.Changes)
+	for _, section := range e.Sections {
+		n += len(section.Changes)
+	}
+	return n
+}
+
+// addMetrics sets the Metrics of each entry, categorizing changes with
+// changeCategory.
+func addMetrics(entries []ChangelogEntry) {
+	for i := range entries {
+		m := &Metrics{Changes: entries[i].changeCount(), ByCategory: make(map[string]int)}
+		for _, section := range entries[i].Sections {
+			for _, change := range section.Changes {
+				m.ByCategory[changeCategory(section.Category, change)]++
+			}
+		}
+		for _, change := range entries[i].Changes {
+			m.ByCategory[changeCategory("", change)]++
+		}
+		m.Breaking = m.ByCategory["breaking"]
+		entries[i].Metrics = m
+	}
+}
+
+// String summarizes the metrics, e.g. "12 changes: 3 added, 8 fixed,
+// 1 breaking".
+func (m *Metrics) String() string {
+	var parts []string
+	for _, c := range diffCategories {
+		if n := m.ByCategory[c.category]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, c.category))
+		}
+	}
+	noun := "changes"
+	if m.Changes == 1 {
+		noun = "change"
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("%d %s", m.Changes, noun)
+	}
+	return fmt.Sprintf("%d %s: %s", m.Changes, noun, strings.Join(parts, ", "))
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// runMultiCommand shows several sources at once (every source for "all"),
+// fetched concurrently and labeled by source. It supports the plain, -md,
+// -pretty, -json and -jsonl formats and the -n, -all and -pre flags; -json
+// writes one document keyed by source name.
+func runMultiCommand(names []string, args []string) {
+	var format string
+	var count int
+	var allEntries, includePre, keepMarkdown bool
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-json", "--json", "-jsonl", "--jsonl", "-md", "--md", "-pretty", "--pretty":
+			format = strings.TrimLeft(args[i], "-")
+		case "-all", "--all":
+			allEntries = true
+		case "-pre", "--pre":
+			includePre = true
+		case "-keep-md", "--keep-md":
+			keepMarkdown = true
+		case "-n":
+			if i+1 < len(args) {
+				n, err := strconv.Atoi(args[i+1])
+				if err != nil || n < 1 {
+					fmt.Fprintf(os.Stderr, "Error: -n expects a positive number, got '%s'\n", args[i+1])
+					os.Exit(1)
+				}
+				count = n
+				i++
+			}
+		default:
+			fmt.Fprintf(os.Stderr, "Warning: %s isn't supported with several sources, ignoring it\n", args[i])
+		}
+	}
+
+	named := make(map[string]Source)
+	if len(names) == 1 && names[0] == "all" {
+		names = names[:0]
+		for name, src := range sources {
+			named[name] = src
+		}
+	} else {
+		for _, name := range names {
+			named[name] = mustLookupSource(name)
+		}
+	}
+
+	// Keep the order sources were named in; "all" is alphabetical
+	results := fetchSources(named)
+	if len(names) > 0 {
+		byName := make(map[string]sourceResult)
+		for _, r := range results {
+			byName[r.name] = r
+		}
+		results = results[:0]
+		for _, name := range names {
+			if r, ok := byName[name]; ok {
+				results = append(results, r)
+				delete(byName, name)
+			}
+		}
+	}
+
+	combined := make(map[string][]ChangelogEntry)
+	var shown int
+	for _, r := range results {
+		if r.err != nil {
+			continue
+		}
+		entries := r.entries
+		if stable := filterChannel(entries, false); !includePre && len(stable) > 0 {
+			entries = stable
+		}
+		switch {
+		case allEntries:
+		case count > 0:
+			entries = entries[:min(count, len(entries))]
+		default:
+			entries = entries[:min(1, len(entries))]
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		if format == "" && !keepMarkdown {
+			entries = stripEntriesMarkdown(entries)
+		}
+		if format == "json" || format == "jsonl" {
+			entries = addPlainChanges(entries)
+		}
+
+		switch format {
+		case "json":
+			for i := range entries {
+				entries[i].Source = r.source.DisplayName
+			}
+			combined[r.name] = entries
+		case "jsonl":
+			outputJSONLines(r.source.DisplayName, entries)
+		case "md":
+			if shown > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("# %s\n\n", r.source.DisplayName)
+			for i := range entries {
+				if i > 0 {
+					fmt.Println()
+				}
+				outputMarkdown(&entries[i])
+			}
+		default:
+			for i := range entries {
+				if shown > 0 || i > 0 {
+					fmt.Println()
+				}
+				if format == "pretty" {
+					outputPretty(r.source.DisplayName, &entries[i])
+				} else {
+					outputPlainText(r.source.DisplayName, &entries[i])
+				}
+			}
+		}
+		shown++
+	}
+
+	if format == "json" {
+		writeJSON(combined)
+	}
+	if shown == 0 {
+		fmt.Fprintf(os.Stderr, "Error: No changelog entries found\n")
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+type opmlDocument struct {
+	XMLName xml.Name    `xml:"opml"`
+	Version string      `xml:"version,attr"`
+	Title   string      `xml:"head>title"`
+	Outline opmlOutline `xml:"body>outline"`
+}
+
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr,omitempty"`
+	Type     string        `xml:"type,attr,omitempty"`
+	XMLURL   string        `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL  string        `xml:"htmlUrl,attr,omitempty"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// runOPMLCommand lists a feed for every source. With a base URL, feeds are
+// expected at <base>/<name>.xml, as published by "aic <name> -rss -all".
+// Without one, GitHub's own release feeds are used and sources that aren't
+// on GitHub are skipped.
+func runOPMLCommand(base string) {
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	folder := opmlOutline{Text: "AI coding tools"}
+	var skipped []string
+	for _, name := range names {
+		src := sources[name]
+		outline := opmlOutline{Text: src.DisplayName, Title: src.DisplayName, Type: "rss", HTMLURL: src.URL()}
+		switch {
+		case base != "":
+			outline.XMLURL = strings.TrimSuffix(base, "/") + "/" + name + ".xml"
+		case src.Owner != "" && src.ChangelogPath == "":
+			outline.XMLURL = fmt.Sprintf("https://github.com/%s/%s/releases.atom", src.Owner, src.Repo)
+		default:
+			skipped = append(skipped, name)
+			continue
+		}
+		folder.Outlines = append(folder.Outlines, outline)
+	}
+
+	if len(skipped) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: No feed URL for %s (use -base with feeds generated by -rss)\n", strings.Join(skipped, ", "))
+	}
+
+	writeXML(opmlDocument{Version: "2.0", Title: "aic changelogs", Outline: folder})
+}
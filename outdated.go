@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type outdatedTool struct {
+	Source    string `json:"source"`
+	Name      string `json:"name"`
+	Installed string `json:"installed"`
+	Latest    string `json:"latest"`
+	Behind    int    `json:"behind"`
+}
+
+// runOutdatedCommand compares the installed version of every detectable
+// tool with its latest stable release and lists the ones with updates.
+// Tools that aren't installed are skipped.
+func runOutdatedCommand(jsonOutput bool) {
+	var detectable []string
+	for name, src := range sources {
+		if src.Command != "" || src.NpmPackage != "" {
+			detectable = append(detectable, name)
+		}
+	}
+	versions := make([]string, len(detectable))
+	forEachBounded(len(detectable), func(i int) {
+		versions[i], _ = sources[detectable[i]].InstalledVersion()
+	})
+
+	installed := make(map[string]string)
+	named := make(map[string]Source)
+	for i, name := range detectable {
+		if versions[i] != "" {
+			installed[name] = versions[i]
+			named[name] = sources[name]
+		}
+	}
+
+	outdated := []outdatedTool{}
+	for _, r := range fetchSources(named) {
+		if r.err != nil {
+			continue
+		}
+		entries := r.entries
+		if stable := filterChannel(entries, false); len(stable) > 0 {
+			entries = stable
+		}
+		if len(entries) == 0 {
+			continue
+		}
+
+		tool := outdatedTool{Source: r.name, Name: r.source.DisplayName, Installed: installed[r.name], Latest: entries[0].Version}
+		if i := versionIndex(entries, tool.Installed); i >= 0 {
+			tool.Behind = i
+		} else if newer, err := newerThan(entries, tool.Installed); err == nil {
+			tool.Behind = len(newer)
+		}
+		if tool.Behind > 0 {
+			outdated = append(outdated, tool)
+		}
+	}
+	sort.Slice(outdated, func(i, j int) bool { return outdated[i].Name < outdated[j].Name })
+
+	if jsonOutput {
+		writeJSON(outdated)
+		return
+	}
+
+	if len(outdated) == 0 {
+		fmt.Println("All installed tools are up to date")
+		return
+	}
+
+	const (
+		colTool      = 24
+		colInstalled = 14
+		colLatest    = 14
+		colBehind    = 6
+	)
+	border := func(left, mid, right string) {
+		fmt.Printf("%s%s%s%s%s%s%s%s%s\n", left,
+			strings.Repeat("─", colTool+2), mid,
+			strings.Repeat("─", colInstalled+2), mid,
+			strings.Repeat("─", colLatest+2), mid,
+			strings.Repeat("─", colBehind+2), right)
+	}
+
+	border("┌", "┬", "┐")
+	fmt.Printf("│ %-*s │ %-*s │ %-*s │ %-*s │\n",
+		colTool, "Tool", colInstalled, "Installed", colLatest, "Latest", colBehind, "Behind")
+	border("├", "┼", "┤")
+	for _, t := range outdated {
+		fmt.Printf("│ %-*s │ %-*s │ %-*s │ %*d │\n",
+			colTool, truncateString(t.Name, colTool),
+			colInstalled, truncateString(t.Installed, colInstalled),
+			colLatest, truncateString(t.Latest, colLatest),
+			colBehind, t.Behind)
+	}
+	border("└", "┴", "┘")
+}
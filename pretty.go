@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	ansiReset     = "\x1b[0m"
+	ansiBold      = "\x1b[1m"
+	ansiDim       = "\x1b[2m"
+	ansiUnderline = "\x1b[4m"
+	ansiCyan      = "\x1b[36m"
+	ansiYellow    = "\x1b[33m"
+	ansiMagenta   = "\x1b[35m"
+)
+
+var (
+	mdLinkRegex   = regexp.MustCompile(`\[([^\[\]]+)\]\((https?://[^)\s]+)\)`)
+	mdBoldRegex   = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	mdCodeRegex   = regexp.MustCompile("`([^`]+)`")
+	ansiCodeRegex = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+)
+
+// outputPretty renders an entry for the terminal, styling inline markdown with
+// ANSI escapes and wrapping changes to the terminal width.
+func outputPretty(displayName string, entry *ChangelogEntry) {
+	width := terminalWidth()
+
+	header := ansiBold + ansiCyan + displayName + ansiReset + " " + ansiBold + entry.Version + ansiReset
+	if !entry.ReleasedAt.IsZero() {
+		header += " " + ansiDim + entry.ReleasedAt.Format("2006-01-02") + ansiReset
+	}
+	fmt.Println(header)
+	fmt.Println(ansiDim + strings.Repeat("─", min(width, 60)) + ansiReset)
+
+	for _, section := range entry.Sections {
+		fmt.Printf("\n%s%s%s\n", ansiBold+ansiYellow, section.Name, ansiReset)
+		for _, change := range section.Changes {
+			printPrettyChange(change, width)
+		}
+	}
+
+	if len(entry.Sections) > 0 && len(entry.Changes) > 0 {
+		fmt.Println()
+	}
+	for _, change := range entry.Changes {
+		printPrettyChange(change, width)
+	}
+}
+
+// printPrettyChange prints a change as a wrapped bullet, with any nested
+// bullets ("\n  - ...") as wrapped sub-bullets under it.
+func printPrettyChange(change string, width int) {
+	lines := strings.Split(change, "\n")
+	fmt.Println(wrapANSI("  • ", "    ", renderInlineMarkdown(lines[0]), width))
+	for _, line := range lines[1:] {
+		text := strings.TrimLeft(line, " ")
+		pad := "  " + strings.Repeat(" ", len(line)-len(text))
+		text = strings.TrimPrefix(text, "- ")
+		fmt.Println(wrapANSI(pad+"◦ ", pad+"  ", renderInlineMarkdown(text), width))
+	}
+}
+
+// renderInlineMarkdown replaces bold, code spans and links with their ANSI
+// equivalents. Code spans are styled first so their contents aren't
+// reinterpreted.
+func renderInlineMarkdown(s string) string {
+	s = mdCodeRegex.ReplaceAllString(s, ansiMagenta+"$1"+ansiReset)
+	s = mdBoldRegex.ReplaceAllString(s, ansiBold+"$1$2"+ansiReset)
+	s = mdLinkRegex.ReplaceAllString(s, ansiUnderline+"$1"+ansiReset+" "+ansiDim+"($2)"+ansiReset)
+	return s
+}
+
+// wrapANSI word-wraps s to width, ignoring escape sequences when measuring.
+// The first line is prefixed with first and continuation lines with indent.
+func wrapANSI(first, indent, s string, width int) string {
+	var b strings.Builder
+	b.WriteString(first)
+	col := utf8.RuneCountInString(first)
+	for i, word := range strings.Fields(s) {
+		n := utf8.RuneCountInString(ansiCodeRegex.ReplaceAllString(word, ""))
+		if i > 0 {
+			if col+1+n > width {
+				b.WriteString("\n" + indent)
+				col = utf8.RuneCountInString(indent)
+			} else {
+				b.WriteString(" ")
+				col++
+			}
+		}
+		b.WriteString(word)
+		col += n
+	}
+	return b.String()
+}
+
+// terminalWidth uses $COLUMNS when the shell exports it and falls back to 80.
+func terminalWidth() int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 20 {
+		return n
+	}
+	return 80
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// waitForRateLimit makes a rate-limited request wait for the limit to reset
+// and try again (-wait) instead of failing or falling back to the cache.
+var waitForRateLimit bool
+
+// rateLimitNotices remembers the hosts already reported, so fetching many
+// sources from one host doesn't repeat the same message.
+var rateLimitNotices sync.Map
+
+// rateLimitError reports a request refused by a rate limit, as GitHub does
+// with 403 or 429 and X-RateLimit-* headers.
+type rateLimitError struct {
+	Host  string
+	Limit int
+	Reset time.Time
+}
+
+func (e *rateLimitError) Error() string {
+	return e.describe() + "; pass -wait to wait for it"
+}
+
+func (e *rateLimitError) describe() string {
+	msg := e.Host + " rate limit exceeded"
+	var details []string
+	if e.Limit > 0 {
+		details = append(details, fmt.Sprintf("0 of %d requests left", e.Limit))
+	}
+	if !e.Reset.IsZero() {
+		details = append(details, fmt.Sprintf("resets at %s, in %s", e.Reset.Local().Format("15:04"), e.untilReset().Round(time.Second)))
+	}
+	if len(details) > 0 {
+		msg += " (" + strings.Join(details, ", ") + ")"
+	}
+	return msg
+}
+
+func (e *rateLimitError) untilReset() time.Duration {
+	if e.Reset.IsZero() {
+		return time.Minute
+	}
+	return max(time.Until(e.Reset), 0)
+}
+
+// wait sleeps until the limit resets, plus a second of slack for clock skew.
+func (e *rateLimitError) wait() {
+	d := e.untilReset() + time.Second
+	if _, reported := rateLimitNotices.LoadOrStore("wait "+e.Host, true); !reported {
+		fmt.Fprintf(os.Stderr, "Waiting %s for the %s rate limit to reset...\n", d.Round(time.Second), e.Host)
+	}
+	time.Sleep(d)
+}
+
+// rateLimitFrom returns the rate limit that refused resp, or nil if resp
+// wasn't rate limited. A 403 only counts when X-RateLimit-Remaining says the
+// quota is used up, since it also means plain forbidden.
+func rateLimitFrom(resp *http.Response) *rateLimitError {
+	exhausted := resp.Header.Get("X-RateLimit-Remaining") == "0"
+	if resp.StatusCode != http.StatusTooManyRequests && (resp.StatusCode != http.StatusForbidden || !exhausted) {
+		return nil
+	}
+
+	e := &rateLimitError{Host: resp.Request.URL.Host}
+	e.Limit, _ = strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+	if secs, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil && exhausted {
+		e.Reset = time.Unix(secs, 0)
+	} else if after, ok := retryAfter(resp); ok {
+		e.Reset = time.Now().Add(after)
+	}
+	return e
+}
+
+// warnStaleCache reports falling back to a cached response because of limit.
+func warnStaleCache(limit *rateLimitError, cached *cachedResponse) {
+	if _, reported := rateLimitNotices.LoadOrStore("stale "+limit.Host, true); !reported {
+		fmt.Fprintf(os.Stderr, "Warning: %s; showing cached data from %s\n", limit.describe(), formatRelativeTime(cached.FetchedAt))
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+var (
+	issueRefRegex = regexp.MustCompile(`(^|[^\w/\[&])#(\d+)\b`)
+	bareRefRegex  = regexp.MustCompile(`(^|[^(\[<\w])https://github\.com/([\w.-]+/[\w.-]+)/(?:pull|issues)/(\d+)\b`)
+)
+
+// Ref is an issue or pull request referenced by a change.
+type Ref struct {
+	Repo   string `json:"repo"`
+	Number int    `json:"number"`
+	URL    string `json:"url"`
+}
+
+// linkGitHubRefs turns "#123" references and bare issue/PR URLs in the
+// changes of a GitHub-hosted project into markdown links, so they still work
+// when the output is pasted elsewhere, and collects them in each entry's
+// Refs.
+func linkGitHubRefs(entries []ChangelogEntry, owner, repo string) {
+	for i := range entries {
+		seen := make(map[string]bool)
+		link := func(change string) string {
+			change = bareRefRegex.ReplaceAllStringFunc(change, func(m string) string {
+				sub := bareRefRegex.FindStringSubmatch(m)
+				text := "#" + sub[3]
+				if sub[2] != owner+"/"+repo {
+					text = sub[2] + text
+				}
+				entries[i].addRef(seen, sub[2], sub[3], m[len(sub[1]):])
+				return fmt.Sprintf("%s[%s](%s)", sub[1], text, m[len(sub[1]):])
+			})
+			return issueRefRegex.ReplaceAllStringFunc(change, func(m string) string {
+				sub := issueRefRegex.FindStringSubmatch(m)
+				url := entries[i].addRef(seen, owner+"/"+repo, sub[2], "")
+				return fmt.Sprintf("%s[#%s](%s)", sub[1], sub[2], url)
+			})
+		}
+
+		for j := range entries[i].Sections {
+			for k, change := range entries[i].Sections[j].Changes {
+				entries[i].Sections[j].Changes[k] = link(change)
+			}
+		}
+		for k, change := range entries[i].Changes {
+			entries[i].Changes[k] = link(change)
+		}
+	}
+}
+
+// addRef records a reference once per entry and returns its URL. Without a
+// known URL it links to /issues/, which GitHub redirects for pull requests.
+func (e *ChangelogEntry) addRef(seen map[string]bool, repo, number, url string) string {
+	if url == "" {
+		url = fmt.Sprintf("https://github.com/%s/issues/%s", repo, number)
+	}
+	if !seen[repo+"#"+number] {
+		seen[repo+"#"+number] = true
+		n, _ := strconv.Atoi(number)
+		e.Refs = append(e.Refs, Ref{Repo: repo, Number: n, URL: url})
+	}
+	return url
+}
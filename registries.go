@@ -0,0 +1,563 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+var (
+	githubRepoURLRegex  = regexp.MustCompile(`github\.com[/:]([\w.-]+)/([\w.-]+?)(?:\.git)?(?:[/#?].*)?$`)
+	pypiPrereleaseRegex = regexp.MustCompile(`\d(a|b|rc|\.dev)\d*`)
+)
+
+// githubRepoFromURL extracts owner and repo from a GitHub repository URL in
+// any of the forms package registries use (git+https, git@, plain).
+func githubRepoFromURL(u string) (owner, repo string, ok bool) {
+	m := githubRepoURLRegex.FindStringSubmatch(u)
+	if m == nil {
+		return "", "", false
+	}
+	return m[1], m[2], true
+}
+
+func npmSource(pkg string) (Source, error) {
+	if pkg == "" {
+		return Source{}, fmt.Errorf("missing npm package name")
+	}
+	return Source{
+		DisplayName:  pkg,
+		ChangelogURL: "https://www.npmjs.com/package/" + pkg,
+		FetchFunc: func() ([]ChangelogEntry, error) {
+			return fetchNpmPackage(pkg)
+		},
+	}, nil
+}
+
+// fetchNpmPackage lists every published version of an npm package, newest
+// first. The registry has no release notes, so when the package links a
+// GitHub repository its releases (or CHANGELOG.md) fill in the changes.
+func fetchNpmPackage(pkg string) ([]ChangelogEntry, error) {
+	body, err := fetchURL("https://registry.npmjs.org/" + url.PathEscape(pkg))
+	if err != nil {
+		return nil, err
+	}
+
+	var doc struct {
+		Time       map[string]string `json:"time"`
+		Versions   map[string]any    `json:"versions"`
+		Repository struct {
+			URL string `json:"url"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse npm metadata: %w", err)
+	}
+
+	var entries []ChangelogEntry
+	for ver := range doc.Versions {
+		releasedAt, _ := time.Parse(time.RFC3339, doc.Time[ver])
+		entries = append(entries, ChangelogEntry{
+			Version:    ver,
+			ReleasedAt: releasedAt,
+			Prerelease: strings.Contains(ver, "-"),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ReleasedAt.After(entries[j].ReleasedAt)
+	})
+
+	if owner, repo, ok := githubRepoFromURL(doc.Repository.URL); ok {
+		// Notes are best effort; the version list is what npm is for
+		if notes, err := fetchGitHubReleasesOrChangelog(owner, repo, 1); err == nil {
+			attachNotes(entries, notes)
+		}
+	}
+
+	return entries, nil
+}
+
+func pypiSource(pkg string) (Source, error) {
+	if pkg == "" {
+		return Source{}, fmt.Errorf("missing PyPI package name")
+	}
+	return Source{
+		DisplayName:  pkg,
+		ChangelogURL: "https://pypi.org/project/" + pkg + "/#history",
+		FetchFunc: func() ([]ChangelogEntry, error) {
+			return fetchPyPIPackage(pkg)
+		},
+	}, nil
+}
+
+// fetchPyPIPackage lists the releases of a PyPI project from the JSON API.
+// Like npm, PyPI only carries the latest README, so notes come from the
+// GitHub repository named in the project URLs, if any.
+func fetchPyPIPackage(pkg string) ([]ChangelogEntry, error) {
+	body, err := fetchURL("https://pypi.org/pypi/" + url.PathEscape(pkg) + "/json")
+	if err != nil {
+		return nil, err
+	}
+
+	var doc struct {
+		Info struct {
+			ProjectURLs map[string]string `json:"project_urls"`
+		} `json:"info"`
+		Releases map[string][]struct {
+			UploadTime string `json:"upload_time_iso_8601"`
+			Yanked     bool   `json:"yanked"`
+		} `json:"releases"`
+	}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse PyPI metadata: %w", err)
+	}
+
+	var entries []ChangelogEntry
+	for ver, files := range doc.Releases {
+		var releasedAt time.Time
+		yanked := len(files) > 0
+		for _, f := range files {
+			yanked = yanked && f.Yanked
+			if t, err := time.Parse(time.RFC3339, f.UploadTime); err == nil && (releasedAt.IsZero() || t.Before(releasedAt)) {
+				releasedAt = t
+			}
+		}
+		if len(files) == 0 || yanked {
+			continue
+		}
+		entries = append(entries, ChangelogEntry{
+			Version:    ver,
+			ReleasedAt: releasedAt,
+			Prerelease: pypiPrereleaseRegex.MatchString(ver),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ReleasedAt.After(entries[j].ReleasedAt)
+	})
+
+	labels := make([]string, 0, len(doc.Info.ProjectURLs))
+	for label := range doc.Info.ProjectURLs {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		if owner, repo, ok := githubRepoFromURL(doc.Info.ProjectURLs[label]); ok {
+			if notes, err := fetchGitHubReleasesOrChangelog(owner, repo, 1); err == nil {
+				attachNotes(entries, notes)
+			}
+			break
+		}
+	}
+
+	return entries, nil
+}
+
+func crateSource(name string) (Source, error) {
+	if name == "" {
+		return Source{}, fmt.Errorf("missing crate name")
+	}
+	return Source{
+		DisplayName:  name,
+		ChangelogURL: "https://crates.io/crates/" + name + "/versions",
+		FetchFunc: func() ([]ChangelogEntry, error) {
+			return fetchCrate(name)
+		},
+	}, nil
+}
+
+// fetchCrate lists the published versions of a crate from the crates.io API,
+// with notes from the crate's GitHub repository when it has one.
+func fetchCrate(name string) ([]ChangelogEntry, error) {
+	body, err := fetchURL("https://crates.io/api/v1/crates/" + url.PathEscape(name))
+	if err != nil {
+		return nil, err
+	}
+
+	var doc struct {
+		Crate struct {
+			Repository string `json:"repository"`
+		} `json:"crate"`
+		Versions []struct {
+			Num       string `json:"num"`
+			CreatedAt string `json:"created_at"`
+			Yanked    bool   `json:"yanked"`
+		} `json:"versions"`
+	}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse crate metadata: %w", err)
+	}
+
+	var entries []ChangelogEntry
+	for _, v := range doc.Versions {
+		if v.Yanked {
+			continue
+		}
+		releasedAt, _ := time.Parse(time.RFC3339, v.CreatedAt)
+		entries = append(entries, ChangelogEntry{
+			Version:    v.Num,
+			ReleasedAt: releasedAt,
+			Prerelease: strings.Contains(v.Num, "-"),
+		})
+	}
+
+	if owner, repo, ok := githubRepoFromURL(doc.Crate.Repository); ok {
+		if notes, err := fetchGitHubReleasesOrChangelog(owner, repo, 1); err == nil {
+			attachNotes(entries, notes)
+		}
+	}
+
+	return entries, nil
+}
+
+func brewSource(name string) (Source, error) {
+	if name == "" {
+		return Source{}, fmt.Errorf("missing Homebrew formula or cask name")
+	}
+	kind := brewKind(name)
+	return Source{
+		DisplayName:  name + " (Homebrew)",
+		ChangelogURL: "https://formulae.brew.sh/" + kind + "/" + name,
+		FetchFunc: func() ([]ChangelogEntry, error) {
+			return fetchBrewPackage(kind, name)
+		},
+	}, nil
+}
+
+// brewKind returns "cask" if Homebrew has no formula called name, and
+// "formula" otherwise, including when the lookup fails; the fetch reports
+// that error. The response is cached, so the fetch reuses it.
+func brewKind(name string) string {
+	_, err := fetchURL(brewAPIURL("formula", name))
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		return "cask"
+	}
+	return "formula"
+}
+
+func brewAPIURL(kind, name string) string {
+	return "https://formulae.brew.sh/api/" + kind + "/" + url.PathEscape(name) + ".json"
+}
+
+// fetchBrewPackage reports the version Homebrew currently packages for a
+// formula or cask. The API only knows the current
+// version, so the result is a single entry; when the package comes from
+// GitHub it notes whether Homebrew has caught up with the latest release.
+func fetchBrewPackage(kind, name string) ([]ChangelogEntry, error) {
+	var doc struct {
+		Version  string `json:"version"`
+		Homepage string `json:"homepage"`
+		Versions struct {
+			Stable string `json:"stable"`
+		} `json:"versions"`
+		URLs struct {
+			Stable struct {
+				URL string `json:"url"`
+			} `json:"stable"`
+		} `json:"urls"`
+		URL string `json:"url"`
+	}
+
+	body, err := fetchURL(brewAPIURL(kind, name))
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse Homebrew metadata: %w", err)
+	}
+
+	ver := doc.Versions.Stable
+	if ver == "" {
+		// Casks carry "version,build" in a single field
+		ver, _, _ = strings.Cut(doc.Version, ",")
+	}
+	entry := ChangelogEntry{
+		Version: ver,
+		URL:     "https://formulae.brew.sh/" + kind + "/" + name,
+		Changes: []string{fmt.Sprintf("Homebrew %s %s is at %s", kind, name, ver)},
+	}
+
+	for _, u := range []string{doc.URLs.Stable.URL, doc.URL, doc.Homepage} {
+		owner, repo, ok := githubRepoFromURL(u)
+		if !ok {
+			continue
+		}
+		upstream, err := fetchGitHubReleases(owner, repo, "", 1)
+		if err == nil && len(upstream) > 0 {
+			// The newest stable release, as the main view picks it
+			latest := sortBySemver(upstream)
+			if stable := filterChannel(latest, false); len(stable) > 0 {
+				latest = stable
+			}
+			status := "up to date"
+			if normalizeVersion(latest[0].Version) != normalizeVersion(ver) {
+				status = "Homebrew may be behind"
+			}
+			entry.Changes = append(entry.Changes, fmt.Sprintf("Latest upstream release is %s (%s)", latest[0].Version, status))
+		}
+		break
+	}
+
+	return []ChangelogEntry{entry}, nil
+}
+
+// dockerSource accepts Docker Hub images ("nginx", "org/image") and GHCR
+// images ("ghcr.io/owner/image").
+func dockerSource(image string) (Source, error) {
+	if image == "" {
+		return Source{}, fmt.Errorf("missing image name")
+	}
+	if path, ok := strings.CutPrefix(image, "ghcr.io/"); ok {
+		owner, name, _ := strings.Cut(path, "/")
+		return Source{
+			DisplayName:  image,
+			ChangelogURL: fmt.Sprintf("https://github.com/%s/pkgs/container/%s", owner, name),
+			FetchFunc: func() ([]ChangelogEntry, error) {
+				return fetchGHCRTags(path)
+			},
+		}, nil
+	}
+
+	repo := strings.TrimPrefix(image, "docker.io/")
+	if !strings.Contains(repo, "/") {
+		repo = "library/" + repo
+	}
+	return Source{
+		DisplayName:  image,
+		ChangelogURL: "https://hub.docker.com/r/" + repo + "/tags",
+		FetchFunc: func() ([]ChangelogEntry, error) {
+			return fetchDockerHubTags(repo)
+		},
+	}, nil
+}
+
+// versionTagRegex matches image tags that name a version ("1.2", "v1.2.3",
+// "3.12-slim") rather than floating tags like "latest", "main" or "sha-1a2b3c".
+var versionTagRegex = regexp.MustCompile(`^v?\d+(\.\d+)+([-+][\w.+-]*)?$`)
+
+// isVersionTag reports whether tag names a version that sorts as semver.
+func isVersionTag(tag string) bool {
+	if !versionTagRegex.MatchString(tag) || datedVersionRegex.MatchString(tag) {
+		return false
+	}
+	_, err := semver.NewVersion(tag)
+	return err == nil
+}
+
+// fetchDockerHubTags lists an image's version tags, with the push date,
+// digest and architectures of each. Floating tags are skipped unless the
+// image has nothing else.
+func fetchDockerHubTags(repo string) ([]ChangelogEntry, error) {
+	body, err := fetchURL("https://hub.docker.com/v2/repositories/" + repo + "/tags?page_size=100&ordering=last_updated")
+	if err != nil {
+		return nil, err
+	}
+
+	var doc struct {
+		Results []struct {
+			Name          string `json:"name"`
+			Digest        string `json:"digest"`
+			TagLastPushed string `json:"tag_last_pushed"`
+			Images        []struct {
+				Architecture string `json:"architecture"`
+			} `json:"images"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse Docker Hub tags: %w", err)
+	}
+
+	var entries, floating []ChangelogEntry
+	for _, tag := range doc.Results {
+		pushedAt, _ := time.Parse(time.RFC3339, tag.TagLastPushed)
+		var changes []string
+		if tag.Digest != "" {
+			changes = append(changes, "Digest: "+tag.Digest)
+		}
+		var archs []string
+		for _, img := range tag.Images {
+			if img.Architecture != "" && img.Architecture != "unknown" {
+				archs = append(archs, img.Architecture)
+			}
+		}
+		if len(archs) > 0 {
+			changes = append(changes, "Architectures: "+strings.Join(archs, ", "))
+		}
+		entry := ChangelogEntry{
+			Version:    tag.Name,
+			ReleasedAt: pushedAt,
+			Changes:    changes,
+		}
+		if isVersionTag(tag.Name) {
+			entries = append(entries, entry)
+		} else {
+			floating = append(floating, entry)
+		}
+	}
+	if len(entries) == 0 {
+		return floating, nil
+	}
+
+	return entries, nil
+}
+
+// ghcrDetailTags caps how many of the newest GHCR version tags get a digest
+// and creation date, since the tag list has neither and each tag takes two
+// or three more requests.
+const ghcrDetailTags = 10
+
+// ghcrManifestAccept asks for multi-platform indexes as well as single
+// image manifests, in both the OCI and Docker formats.
+var ghcrManifestAccept = strings.Join([]string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}, ", ")
+
+// fetchGHCRTags lists an image's version tags from the GitHub Container
+// Registry using an anonymous pull token, newest version first. The newest
+// ghcrDetailTags tags also get their digest, architectures and creation
+// date; older ones carry the tag name only. Floating tags are skipped unless
+// the image has nothing else.
+func fetchGHCRTags(path string) ([]ChangelogEntry, error) {
+	body, err := fetchURLUncached("https://ghcr.io/token?scope=repository:"+path+":pull", nil)
+	if err != nil {
+		return nil, err
+	}
+	var auth struct {
+		Token string `json:"token"`
+	}
+	if err := json.Unmarshal(body, &auth); err != nil {
+		return nil, fmt.Errorf("failed to parse registry token: %w", err)
+	}
+
+	body, err = fetchURLWithHeaders("https://ghcr.io/v2/"+path+"/tags/list?n=1000", map[string]string{
+		"Authorization": "Bearer " + auth.Token,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var list struct {
+		Tags []string `json:"tags"`
+	}
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse registry tags: %w", err)
+	}
+
+	var entries, floating []ChangelogEntry
+	for _, tag := range list.Tags {
+		if isVersionTag(tag) {
+			entries = append(entries, ChangelogEntry{Version: tag})
+		} else {
+			floating = append(floating, ChangelogEntry{Version: tag})
+		}
+	}
+	if len(entries) == 0 {
+		return floating, nil
+	}
+
+	entries = sortBySemver(entries)
+	forEachBounded(min(len(entries), ghcrDetailTags), func(i int) {
+		entry := &entries[i]
+		digest, archs, created, err := fetchGHCRImage(path, entry.Version, auth.Token)
+		if err != nil {
+			return
+		}
+		entry.ReleasedAt = created
+		entry.Changes = append(entry.Changes, "Digest: "+digest)
+		if len(archs) > 0 {
+			entry.Changes = append(entry.Changes, "Architectures: "+strings.Join(archs, ", "))
+		}
+	})
+
+	return entries, nil
+}
+
+// fetchGHCRImage returns the digest of the manifest tag points to, the
+// architectures it's built for and when its image was created. The digest
+// is the manifest's sha256, which is what Docker-Content-Digest reports, so
+// the manifest fetch gives it without a separate HEAD request. The date is
+// "created" from the image config, read from the first image of an index.
+func fetchGHCRImage(path, tag, token string) (digest string, archs []string, created time.Time, err error) {
+	headers := map[string]string{
+		"Authorization": "Bearer " + token,
+		"Accept":        ghcrManifestAccept,
+	}
+	body, err := fetchURLWithHeaders("https://ghcr.io/v2/"+path+"/manifests/"+url.PathEscape(tag), headers)
+	if err != nil {
+		return "", nil, time.Time{}, err
+	}
+	digest = fmt.Sprintf("sha256:%x", sha256.Sum256(body))
+
+	var manifest struct {
+		Manifests []struct {
+			Digest   string `json:"digest"`
+			Platform struct {
+				Architecture string `json:"architecture"`
+				OS           string `json:"os"`
+			} `json:"platform"`
+		} `json:"manifests"`
+		Config struct {
+			Digest string `json:"digest"`
+		} `json:"config"`
+	}
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return "", nil, time.Time{}, fmt.Errorf("failed to parse image manifest: %w", err)
+	}
+
+	if len(manifest.Manifests) > 0 {
+		// Attestations are listed as images for the "unknown" platform
+		var image string
+		for _, m := range manifest.Manifests {
+			if m.Platform.OS == "unknown" {
+				continue
+			}
+			archs = append(archs, m.Platform.Architecture)
+			if image == "" {
+				image = m.Digest
+			}
+		}
+		if image == "" {
+			return digest, archs, time.Time{}, nil
+		}
+		body, err = fetchURLWithHeaders("https://ghcr.io/v2/"+path+"/manifests/"+image, headers)
+		if err != nil {
+			return "", nil, time.Time{}, err
+		}
+		if err := json.Unmarshal(body, &manifest); err != nil {
+			return "", nil, time.Time{}, fmt.Errorf("failed to parse image manifest: %w", err)
+		}
+	}
+	if manifest.Config.Digest == "" {
+		return digest, archs, time.Time{}, nil
+	}
+
+	body, err = fetchURLWithHeaders("https://ghcr.io/v2/"+path+"/blobs/"+manifest.Config.Digest, map[string]string{
+		"Authorization": "Bearer " + token,
+	})
+	if err != nil {
+		return "", nil, time.Time{}, err
+	}
+	var config struct {
+		Created      string `json:"created"`
+		Architecture string `json:"architecture"`
+	}
+	if err := json.Unmarshal(body, &config); err != nil {
+		return "", nil, time.Time{}, fmt.Errorf("failed to parse image config: %w", err)
+	}
+	created, _ = time.Parse(time.RFC3339, config.Created)
+	if len(archs) == 0 && config.Architecture != "" {
+		archs = []string{config.Architecture}
+	}
+	return digest, archs, created, nil
+}
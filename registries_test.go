@@ -0,0 +1,23 @@
+package main
+
+import "testing"
+
+func TestIsVersionTag(t *testing.T) {
+	tests := map[string]bool{
+		"1.2.3":      true,
+		"v1.2":       true,
+		"10.0.0":     true,
+		"3.12-slim":  true,
+		"1.2.3-rc.1": true,
+		"latest":     false,
+		"main":       false,
+		"sha-1a2b3c": false,
+		"1234567":    false,
+		"2025-01-02": false,
+	}
+	for tag, want := range tests {
+		if got := isVersionTag(tag); got != want {
+			t.Errorf("isVersionTag(%q) = %v, want %v", tag, got, want)
+		}
+	}
+}
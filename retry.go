@@ -0,0 +1,87 @@
+package main
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxRetries is how many times a request is retried after a transient
+// failure; retries in sources.yaml or -retries override it.
+var maxRetries = 3
+
+const (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 30 * time.Second
+)
+
+// doWithRetry sends req, retrying network errors, 429 Too Many Requests and
+// 5xx responses with exponential backoff and jitter. A Retry-After header
+// takes precedence over the backoff, unless it asks for a longer wait than
+// retryMaxDelay. The last response or error is returned as-is.
+func doWithRetry(client *http.Client, req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		// A request body is consumed by every attempt, including ones made
+		// again after waiting out a rate limit
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := client.Do(req)
+		if attempt >= maxRetries || !retryable(resp, err) {
+			return resp, err
+		}
+
+		delay := backoff(attempt)
+		if resp != nil {
+			if after, ok := retryAfter(resp); ok {
+				if after > retryMaxDelay {
+					return resp, err
+				}
+				delay = after
+			}
+			resp.Body.Close()
+		}
+		time.Sleep(delay)
+	}
+}
+
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	// An exhausted quota won't come back within a few retries; that's left
+	// to rateLimitFrom and -wait
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		return false
+	}
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		(resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented)
+}
+
+// backoff returns the delay before retry attempt+1: retryBaseDelay doubled
+// per attempt, jittered to between half and all of that.
+func backoff(attempt int) time.Duration {
+	d := min(retryBaseDelay<<attempt, retryMaxDelay)
+	return d/2 + rand.N(d/2)
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+)
+
+// runSearchCommand prints the changes mentioning term across every source,
+// grouped by source and version. term is matched case-insensitively as
+// plain text.
+func runSearchCommand(term string, jsonOutput bool) {
+	re := regexp.MustCompile("(?i)" + regexp.QuoteMeta(term))
+
+	var matches []ChangelogEntry
+	for _, r := range fetchAllSources() {
+		if r.err != nil {
+			continue
+		}
+		for _, entry := range grepChanges(r.entries, re) {
+			entry.Source = r.source.DisplayName
+			matches = append(matches, entry)
+		}
+	}
+
+	if jsonOutput {
+		if matches == nil {
+			matches = []ChangelogEntry{}
+		}
+		writeJSON(matches)
+		return
+	}
+
+	if len(matches) == 0 {
+		fmt.Fprintf(os.Stderr, "No changes mention %q\n", term)
+		os.Exit(1)
+	}
+
+	var lastSource string
+	for _, entry := range matches {
+		if entry.Source != lastSource {
+			if lastSource != "" {
+				fmt.Println()
+			}
+			fmt.Println(entry.Source)
+			lastSource = entry.Source
+		}
+		if !entry.ReleasedAt.IsZero() {
+			fmt.Printf("  %s (%s)\n", entry.Version, entry.ReleasedAt.Format("2006-01-02"))
+		} else {
+			fmt.Printf("  %s\n", entry.Version)
+		}
+		for _, section := range entry.Sections {
+			for _, change := range section.Changes {
+				fmt.Printf("    * %s\n", change)
+			}
+		}
+		for _, change := range entry.Changes {
+			fmt.Printf("    * %s\n", change)
+		}
+	}
+}
+
+// runWhenCommand reports the earliest entry of source whose changes mention
+// term, with the matching changes.
+func runWhenCommand(source Source, term string, jsonOutput bool) {
+	entries, err := source.Fetch()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching changelog: %v\n", err)
+		os.Exit(1)
+	}
+
+	re := regexp.MustCompile("(?i)" + regexp.QuoteMeta(term))
+	matches := grepChanges(entries, re)
+	if len(matches) == 0 {
+		fmt.Fprintf(os.Stderr, "No %s changes mention %q\n", source.DisplayName, term)
+		os.Exit(1)
+	}
+
+	// Entries are newest first
+	earliest := matches[len(matches)-1]
+	if jsonOutput {
+		earliest.Source = source.DisplayName
+		outputJSON(&earliest)
+		return
+	}
+	outputPlainText(source.DisplayName, &earliest)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Dated versions ("2025-01-02", "2025-01-02.2") would parse as semver
+// prereleases and sort same-day entries backwards.
+var datedVersionRegex = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}`)
+
+// sortBySemver sorts entries newest first when their versions parse as
+// semver. Versions in other schemes (build numbers, dated versions) follow
+// them in source order, so sources using only those are left as they are.
+func sortBySemver(entries []ChangelogEntry) []ChangelogEntry {
+	versions := make([]*semver.Version, len(entries))
+	for i, e := range entries {
+		if v, err := semver.NewVersion(e.Version); err == nil && !datedVersionRegex.MatchString(e.Version) {
+			versions[i] = v
+		}
+	}
+
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := versions[order[i]], versions[order[j]]
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		return a.GreaterThan(b)
+	})
+
+	sorted := make([]ChangelogEntry, len(entries))
+	for i, n := range order {
+		sorted[i] = entries[n]
+	}
+	return sorted
+}
+
+// newerThan returns the entries with a semver version greater than version,
+// for when version itself isn't in the list.
+func newerThan(entries []ChangelogEntry, version string) ([]ChangelogEntry, error) {
+	base, err := semver.NewVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	var newer []ChangelogEntry
+	for _, e := range entries {
+		if v, err := semver.NewVersion(e.Version); err == nil && v.GreaterThan(base) {
+			newer = append(newer, e)
+		}
+	}
+	return newer, nil
+}
+
+// versionIndex returns the index of version in entries, matching
+// semver-equal versions ("v1.2.3" and "1.2.3") too, or -1.
+func versionIndex(entries []ChangelogEntry, version string) int {
+	for i := range entries {
+		if entries[i].Version == version {
+			return i
+		}
+	}
+	want, err := semver.NewVersion(version)
+	if err != nil {
+		return -1
+	}
+	for i := range entries {
+		if v, err := semver.NewVersion(entries[i].Version); err == nil && v.Equal(want) {
+			return i
+		}
+	}
+	return -1
+}
+
+// normalizeVersion returns the canonical form of a semver version, so
+// "v1.2" and "1.2.0" compare equal; other versions are only lowercased.
+func normalizeVersion(ver string) string {
+	if v, err := semver.NewVersion(ver); err == nil && !datedVersionRegex.MatchString(ver) {
+		return v.String()
+	}
+	return strings.ToLower(ver)
+}
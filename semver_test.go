@@ -0,0 +1,90 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func versions(entries []ChangelogEntry) []string {
+	vs := make([]string, len(entries))
+	for i, e := range entries {
+		vs[i] = e.Version
+	}
+	return vs
+}
+
+func entriesFor(vs ...string) []ChangelogEntry {
+	entries := make([]ChangelogEntry, len(vs))
+	for i, v := range vs {
+		entries[i] = ChangelogEntry{Version: v}
+	}
+	return entries
+}
+
+func TestSortBySemver(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []string
+		want []string
+	}{
+		{"numeric not lexical", []string{"9.0.0", "10.0.0", "1.10.0", "1.9.0"}, []string{"10.0.0", "9.0.0", "1.10.0", "1.9.0"}},
+		{"prefixes and partial versions", []string{"v1.2", "1.3.0", "v1.2.1"}, []string{"1.3.0", "v1.2.1", "v1.2"}},
+		{"prereleases before their release", []string{"2.0.0-rc.1", "2.0.0", "2.0.0-beta.2"}, []string{"2.0.0", "2.0.0-rc.1", "2.0.0-beta.2"}},
+		{"dated versions keep source order", []string{"2025-01-02", "2025-01-02.2", "2025-01-03"}, []string{"2025-01-02", "2025-01-02.2", "2025-01-03"}},
+		{"build numbers keep source order", []string{"b4500", "b4512", "b4499"}, []string{"b4500", "b4512", "b4499"}},
+		{"other schemes after semver in source order", []string{"nightly", "1.0.0", "2025-01-02", "1.1.0"}, []string{"1.1.0", "1.0.0", "nightly", "2025-01-02"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := versions(sortBySemver(entriesFor(tt.in...))); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("sortBySemver(%v) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeVersion(t *testing.T) {
+	tests := map[string]string{
+		"v1.2":        "1.2.0",
+		"1.2.0":       "1.2.0",
+		"v1.2.3-rc.1": "1.2.3-rc.1",
+		"2025-01-02":  "2025-01-02",
+		"Nightly":     "nightly",
+	}
+	for in, want := range tests {
+		if got := normalizeVersion(in); got != want {
+			t.Errorf("normalizeVersion(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestVersionIndex(t *testing.T) {
+	entries := entriesFor("2.0.0", "v1.5.0", "1.4.0")
+	tests := map[string]int{
+		"2.0.0":  0,
+		"1.5.0":  1,
+		"v1.4":   2,
+		"1.3.0":  -1,
+		"latest": -1,
+	}
+	for in, want := range tests {
+		if got := versionIndex(entries, in); got != want {
+			t.Errorf("versionIndex(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestNewerThan(t *testing.T) {
+	entries := entriesFor("2.0.0", "1.5.0", "nightly", "1.4.0")
+	got, err := newerThan(entries, "1.4.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"2.0.0", "1.5.0"}; !reflect.DeepEqual(versions(got), want) {
+		t.Errorf("newerThan(1.4.5) = %v, want %v", versions(got), want)
+	}
+	if _, err := newerThan(entries, "latest"); err == nil {
+		t.Error("newerThan(latest) succeeded, want an error")
+	}
+}
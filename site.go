@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// siteFeedItems caps the combined feed, which would otherwise grow with the
+// full history of every source.
+const siteFeedItems = 50
+
+var siteTemplates = template.Must(template.New("layout").Parse(`{{define "head"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.}}</title>
+<link rel="alternate" type="application/rss+xml" title="All releases" href="feed.xml">
+<style>
+body { font-family: system-ui, sans-serif; max-width: 52rem; margin: 2rem auto; padding: 0 1rem; line-height: 1.5; color: #222; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: .4rem .6rem; border-bottom: 1px solid #ddd; }
+.muted { color: #777; }
+h2 { margin-top: 2rem; border-bottom: 1px solid #ddd; }
+</style>
+</head>
+<body>
+{{end}}
+{{define "index"}}{{template "head" "AI coding tool releases"}}<h1>AI coding tool releases</h1>
+<p class="muted">Generated by aic on {{.Generated}} · <a href="feed.xml">RSS feed</a></p>
+<table>
+<tr><th>Tool</th><th>Version</th><th>Released</th></tr>
+{{range .Sources}}<tr><td><a href="{{.Name}}.html">{{.DisplayName}}</a></td><td>{{.Version}}</td><td>{{.Date}} <span class="muted">{{.Ago}}</span></td></tr>
+{{end}}</table>
+</body>
+</html>
+{{end}}
+{{define "source"}}{{template "head" .DisplayName}}{{if .Name}}<p><a href="index.html">← All tools</a></p>{{end}}
+<h1>{{.DisplayName}}</h1>
+<p class="muted"><a href="{{.URL}}">Upstream changelog</a></p>
+{{range .Entries}}<h2>{{.Version}}{{if .Date}} <span class="muted">{{.Date}}</span>{{end}}</h2>
+{{.Body}}
+{{end}}</body>
+</html>
+{{end}}`))
+
+type siteSource struct {
+	Name        string
+	DisplayName string
+	URL         string
+	Version     string
+	Date        string
+	Ago         string
+	Entries     []siteEntry
+	releasedAt  time.Time
+}
+
+type siteEntry struct {
+	Version string
+	Date    string
+	Body    template.HTML
+}
+
+// runSiteCommand fetches every source and writes a static site to outDir: an
+// index of latest versions, a page per source and a combined RSS feed.
+func runSiteCommand(outDir string) {
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var pages []siteSource
+	type feedItem struct {
+		rssItem
+		releasedAt time.Time
+	}
+	var items []feedItem
+	for _, r := range fetchAllSources() {
+		if r.err != nil {
+			continue
+		}
+		if len(r.entries) == 0 {
+			continue
+		}
+
+		// The index shows the latest stable version, falling back to a
+		// prerelease as the main view does
+		latest := r.entries[0]
+		if stable := filterChannel(r.entries, false); len(stable) > 0 {
+			latest = stable[0]
+		}
+		page := siteSource{
+			Name:        r.name,
+			DisplayName: r.source.DisplayName,
+			URL:         r.source.URL(),
+			Version:     latest.Version,
+			Date:        "-",
+			Ago:         formatRelativeTime(latest.ReleasedAt),
+			releasedAt:  latest.ReleasedAt,
+		}
+		if !page.releasedAt.IsZero() {
+			page.Date = page.releasedAt.Format("2006-01-02")
+		}
+		for _, entry := range r.entries {
+			se := siteEntry{Version: entry.Version, Body: template.HTML(entryHTML(&entry))}
+			if !entry.ReleasedAt.IsZero() {
+				se.Date = entry.ReleasedAt.Format("2006-01-02")
+				items = append(items, feedItem{rssItem{
+					Title:       fmt.Sprintf("%s %s", r.source.DisplayName, entry.Version),
+					Link:        entryURL(r.source, &entry),
+					GUID:        rssGUID{Value: fmt.Sprintf("aic:%s:%s", r.name, entry.Version)},
+					PubDate:     entry.ReleasedAt.Format(time.RFC1123Z),
+					Description: string(se.Body),
+				}, entry.ReleasedAt})
+			}
+			page.Entries = append(page.Entries, se)
+		}
+
+		if err := writeSiteFile(filepath.Join(outDir, r.name+".html"), func(f *os.File) error {
+			return siteTemplates.ExecuteTemplate(f, "source", page)
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		pages = append(pages, page)
+	}
+
+	sort.Slice(pages, func(i, j int) bool {
+		return pages[i].releasedAt.After(pages[j].releasedAt)
+	})
+	index := struct {
+		Generated string
+		Sources   []siteSource
+	}{time.Now().Format("2006-01-02 15:04 MST"), pages}
+	if err := writeSiteFile(filepath.Join(outDir, "index.html"), func(f *os.File) error {
+		return siteTemplates.ExecuteTemplate(f, "index", index)
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].releasedAt.After(items[j].releasedAt)
+	})
+	channel := rssChannel{
+		Title:       "AI coding tool releases",
+		Link:        "index.html",
+		Description: "Releases of every tool tracked by aic",
+	}
+	for i, item := range items {
+		if i == siteFeedItems {
+			break
+		}
+		channel.Items = append(channel.Items, item.rssItem)
+	}
+	if len(items) > 0 {
+		channel.LastBuildDate = items[0].releasedAt.Format(time.RFC1123Z)
+	}
+	if err := writeSiteFile(filepath.Join(outDir, "feed.xml"), func(f *os.File) error {
+		return encodeXML(f, rssFeed{Version: "2.0", Channel: channel})
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Wrote %d source pages to %s\n", len(pages), outDir)
+}
+
+func writeSiteFile(path string, write func(*os.File) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// outputHTML writes entries as a standalone HTML page.
+func outputHTML(source Source, entries []ChangelogEntry) {
+	page := siteSource{DisplayName: source.DisplayName, URL: source.URL()}
+	for _, entry := range entries {
+		se := siteEntry{Version: entry.Version, Body: template.HTML(entryHTML(&entry))}
+		if !entry.ReleasedAt.IsZero() {
+			se.Date = entry.ReleasedAt.Format("2006-01-02")
+		}
+		page.Entries = append(page.Entries, se)
+	}
+	if err := siteTemplates.ExecuteTemplate(os.Stdout, "source", page); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
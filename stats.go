@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// statsPeriods is how many recent weeks and months aic stats breaks down.
+const statsPeriods = 12
+
+type periodCount struct {
+	Period string `json:"period"`
+	Count  int    `json:"count"`
+}
+
+type releaseSize struct {
+	Version    string    `json:"version"`
+	ReleasedAt time.Time `json:"released_at,omitzero"`
+	Changes    int       `json:"changes"`
+}
+
+type releaseStats struct {
+	Source         string        `json:"source"`
+	Releases       int           `json:"releases"`
+	FirstRelease   time.Time     `json:"first_release,omitzero"`
+	LastRelease    time.Time     `json:"last_release,omitzero"`
+	AverageGapDays float64       `json:"average_gap_days"`
+	PerMonth       []periodCount `json:"per_month"`
+	PerWeek        []periodCount `json:"per_week"`
+	Biggest        []releaseSize `json:"biggest"`
+}
+
+// runStatsCommand reports a source's release cadence over its full history:
+// releases per week and month, the average gap between releases and the
+// releases with the most changes.
+func runStatsCommand(source Source, jsonOutput bool) {
+	entries, err := source.withHistory().Fetch()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching changelog: %v\n", err)
+		os.Exit(1)
+	}
+	if stable := filterChannel(entries, false); len(stable) > 0 {
+		entries = stable
+	}
+	if len(entries) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: No changelog entries found\n")
+		os.Exit(1)
+	}
+
+	stats := computeStats(source.DisplayName, entries)
+	if jsonOutput {
+		writeJSON(stats)
+		return
+	}
+
+	fmt.Printf("%s release statistics\n", stats.Source)
+	fmt.Println(strings.Repeat("-", 40))
+	fmt.Printf("Releases:     %d", stats.Releases)
+	if !stats.FirstRelease.IsZero() {
+		fmt.Printf(" (%s to %s)", stats.FirstRelease.Format("2006-01-02"), stats.LastRelease.Format("2006-01-02"))
+	}
+	fmt.Println()
+	if stats.AverageGapDays > 0 {
+		fmt.Printf("Average gap:  %.1f days\n", stats.AverageGapDays)
+	}
+
+	printCounts := func(title string, counts []periodCount) {
+		if len(counts) == 0 {
+			return
+		}
+		fmt.Printf("\n%s\n", title)
+		for _, c := range counts {
+			fmt.Printf("  %-8s  %3d %s\n", c.Period, c.Count, strings.Repeat("█", c.Count))
+		}
+	}
+	printCounts("Per month", stats.PerMonth)
+	printCounts("Per week", stats.PerWeek)
+
+	fmt.Printf("\nBiggest releases\n")
+	for _, r := range stats.Biggest {
+		date := ""
+		if !r.ReleasedAt.IsZero() {
+			date = r.ReleasedAt.Format("2006-01-02")
+		}
+		fmt.Printf("  %-14s  %-10s  %d changes\n", r.Version, date, r.Changes)
+	}
+}
+
+func computeStats(displayName string, entries []ChangelogEntry) releaseStats {
+	stats := releaseStats{Source: displayName, Releases: len(entries)}
+
+	var dates []time.Time
+	for _, e := range entries {
+		if !e.ReleasedAt.IsZero() {
+			dates = append(dates, e.ReleasedAt)
+		}
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	if len(dates) > 0 {
+		stats.FirstRelease, stats.LastRelease = dates[0], dates[len(dates)-1]
+		if len(dates) > 1 {
+			gap := stats.LastRelease.Sub(stats.FirstRelease) / time.Duration(len(dates)-1)
+			stats.AverageGapDays = float64(int(gap.Hours()/24*10)) / 10
+		}
+
+		// Count the most recent periods up to today, including empty ones
+		months := map[string]int{}
+		weeks := map[string]int{}
+		for _, d := range dates {
+			months[d.Format("2006-01")]++
+			year, week := d.ISOWeek()
+			weeks[fmt.Sprintf("%d-W%02d", year, week)]++
+		}
+		now := time.Now().UTC()
+		for i := statsPeriods - 1; i >= 0; i-- {
+			month := time.Date(now.Year(), now.Month()-time.Month(i), 1, 0, 0, 0, 0, time.UTC).Format("2006-01")
+			stats.PerMonth = append(stats.PerMonth, periodCount{Period: month, Count: months[month]})
+
+			year, week := now.AddDate(0, 0, -7*i).ISOWeek()
+			key := fmt.Sprintf("%d-W%02d", year, week)
+			stats.PerWeek = append(stats.PerWeek, periodCount{Period: key, Count: weeks[key]})
+		}
+	}
+
+	for _, e := range entries {
+		stats.Biggest = append(stats.Biggest, releaseSize{Version: e.Version, ReleasedAt: e.ReleasedAt, Changes: e.changeCount()})
+	}
+	sort.SliceStable(stats.Biggest, func(i, j int) bool { return stats.Biggest[i].Changes > stats.Biggest[j].Changes })
+	stats.Biggest = stats.Biggest[:min(5, len(stats.Biggest))]
+
+	return stats
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// maxTagCompares caps how many tag-to-tag comparisons are requested, since
+// each one is a separate API call against a small unauthenticated quota.
+const maxTagCompares = 10
+
+// fetchGitHubTagHistory builds entries for repos without releases or a
+// changelog: one per version tag, with the subjects of the commits since the
+// previous version as its changes. Tags that aren't versions are skipped.
+func fetchGitHubTagHistory(owner, repo string) ([]ChangelogEntry, error) {
+	headers := map[string]string{"Accept": "application/vnd.github+json"}
+
+	body, err := fetchURLWithHeaders(fmt.Sprintf("https://api.github.com/repos/%s/%s/tags?per_page=100", owner, repo), headers)
+	if err != nil {
+		return nil, err
+	}
+	var tags []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(body, &tags); err != nil {
+		return nil, fmt.Errorf("failed to parse tags: %w", err)
+	}
+
+	// Tags are listed by name, so neighbours are only the previous version
+	// once sorted
+	var versions []ChangelogEntry
+	for _, tag := range tags {
+		if isVersionTag(tag.Name) {
+			versions = append(versions, ChangelogEntry{Version: tag.Name})
+		}
+	}
+	versions = sortBySemver(versions)
+	if len(versions) > maxTagCompares+1 {
+		versions = versions[:maxTagCompares+1]
+	}
+
+	var entries []ChangelogEntry
+	for i, tag := range versions {
+		entry := ChangelogEntry{Version: strings.TrimPrefix(tag.Version, "v")}
+		if i+1 < len(versions) {
+			compareURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/compare/%s...%s", owner, repo, url.PathEscape(versions[i+1].Version), url.PathEscape(tag.Version))
+			body, err := fetchURLWithHeaders(compareURL, headers)
+			if err != nil {
+				return nil, err
+			}
+			var cmp struct {
+				Commits []struct {
+					Commit struct {
+						Message   string `json:"message"`
+						Committer struct {
+							Date string `json:"date"`
+						} `json:"committer"`
+					} `json:"commit"`
+				} `json:"commits"`
+			}
+			if err := json.Unmarshal(body, &cmp); err != nil {
+				return nil, fmt.Errorf("failed to parse comparison: %w", err)
+			}
+			// Commits are listed oldest first
+			for j := len(cmp.Commits) - 1; j >= 0; j-- {
+				subject, _, _ := strings.Cut(cmp.Commits[j].Commit.Message, "\n")
+				entry.Changes = append(entry.Changes, subject)
+			}
+			if n := len(cmp.Commits); n > 0 {
+				entry.ReleasedAt, _ = time.Parse(time.RFC3339, cmp.Commits[n-1].Commit.Committer.Date)
+			}
+		} else if len(versions) > maxTagCompares {
+			// The oldest fetched tag only served as a comparison base
+			break
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// templateEntry is the data passed to -template. Changes holds every change,
+// including those under sections, for templates that don't care about
+// grouping, and Watched the watched keywords the entry mentions.
+type templateEntry struct {
+	Source     string
+	Version    string
+	Date       string
+	ReleasedAt time.Time
+	Prerelease bool
+	URL        string
+	Sections   []Section
+	Changes    []string
+	Watched    []string
+}
+
+var templateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// outputTemplate renders each entry with a user-supplied text/template.
+func outputTemplate(displayName, text string, entries []ChangelogEntry) {
+	tmpl, err := template.New("aic").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing template: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, entry := range entries {
+		data := templateEntry{
+			Source:     displayName,
+			Version:    entry.Version,
+			ReleasedAt: entry.ReleasedAt,
+			Prerelease: entry.Prerelease,
+			URL:        entry.URL,
+			Sections:   entry.Sections,
+			Watched:    entry.Watched,
+		}
+		if !entry.ReleasedAt.IsZero() {
+			data.Date = entry.ReleasedAt.Format("2006-01-02")
+		}
+		for _, section := range entry.Sections {
+			data.Changes = append(data.Changes, section.Changes...)
+		}
+		data.Changes = append(data.Changes, entry.Changes...)
+
+		if err := tmpl.Execute(os.Stdout, data); err != nil {
+			fmt.Fprintf(os.Stderr, "Error executing template: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// upgradeCommand works out how the source's tool was installed and returns
+// that method with the command that upgrades it. The tool's own path tells
+// npm and Homebrew installs apart; otherwise the npm and Homebrew package
+// lists are checked, and anything else is treated as a binary download.
+// The command is nil when there's no way to upgrade in place, and the
+// method is empty when the tool isn't installed.
+func (s Source) upgradeCommand() (string, []string) {
+	var path string
+	if s.Command != "" {
+		if p, err := exec.LookPath(s.Command); err == nil {
+			path = p
+			if resolved, err := filepath.EvalSymlinks(p); err == nil {
+				path = resolved
+			}
+		}
+	}
+
+	npm := []string{"npm", "install", "-g", s.NpmPackage + "@latest"}
+	brew := append([]string{"brew", "upgrade"}, strings.Fields(s.Brew)...)
+	switch {
+	case s.NpmPackage != "" && strings.Contains(path, "node_modules"):
+		return "npm", npm
+	case s.Brew != "" && (strings.Contains(path, "/Cellar/") || strings.Contains(path, "/Caskroom/")):
+		return "Homebrew", brew
+	case s.NpmPackage != "" && npmInstalled(s.NpmPackage):
+		return "npm", npm
+	case s.Brew != "" && brewInstalled(s.Brew):
+		return "Homebrew", brew
+	case path == "":
+		return "", nil
+	case s.SelfUpgrade != "":
+		return "its own installer", strings.Fields(s.SelfUpgrade)
+	}
+	return "a binary download", nil
+}
+
+func npmInstalled(pkg string) bool {
+	_, err := runProbe("npm", "ls", "-g", "--depth=0", pkg)
+	return err == nil
+}
+
+func brewInstalled(pkg string) bool {
+	args := append([]string{"list", "--versions"}, strings.Fields(pkg)...)
+	out, err := runProbe("brew", args...)
+	return err == nil && len(strings.TrimSpace(string(out))) > 0
+}
+
+// runUpgradeCommand prints the command that upgrades the source's tool, or
+// with execute, runs it.
+func runUpgradeCommand(source Source, execute bool) {
+	if source.Command == "" && source.NpmPackage == "" && source.Brew == "" {
+		fmt.Fprintf(os.Stderr, "Error: Don't know how %s is installed\n", source.DisplayName)
+		os.Exit(1)
+	}
+
+	method, command := source.upgradeCommand()
+	if method == "" {
+		fmt.Fprintf(os.Stderr, "Error: %s doesn't appear to be installed\n", source.DisplayName)
+		os.Exit(1)
+	}
+	if command == nil {
+		fmt.Fprintf(os.Stderr, "%s looks like %s; get the latest release from:\n", source.DisplayName, method)
+		fmt.Println(source.URL())
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "%s was installed with %s\n", source.DisplayName, method)
+	fmt.Println(strings.Join(command, " "))
+	if !execute {
+		return
+	}
+
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.ExitCode())
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// checksumFileRegex matches combined checksum files such as SHA256SUMS or
+// tool_1.2.3_checksums.txt.
+var checksumFileRegex = regexp.MustCompile(`(?i)(sha256|sha512|checksum)s?(sums?)?(\.txt)?$`)
+
+var checksumSuffixes = []string{".sha256", ".sha256sum", ".sha512", ".sha512sum"}
+
+// verifyAsset checks a downloaded asset against the checksums and minisign
+// signature published with its release, printing a line per check. It
+// returns false if a check failed or there was nothing to check against.
+// minisign signatures are verified with the minisign tool when a public key
+// is given; sigstore bundles are only reported.
+func verifyAsset(entry *ChangelogEntry, asset Asset, file, minisignKey string) bool {
+	verified, ok := false, true
+	report := func(passed bool, format string, args ...any) {
+		mark := "✓"
+		if !passed {
+			mark, ok = "✗", false
+		}
+		fmt.Printf("  %s %s\n", mark, fmt.Sprintf(format, args...))
+	}
+
+	if want, from := publishedChecksum(entry, asset); want != "" {
+		got, err := fileChecksum(file, len(want))
+		switch {
+		case err != nil:
+			report(false, "checksum: %v", err)
+		case got == want:
+			report(true, "checksum matches %s", from)
+		default:
+			report(false, "checksum mismatch with %s: got %s, want %s", from, got, want)
+		}
+		verified = true
+	}
+
+	for _, a := range entry.Assets {
+		switch a.Name {
+		case asset.Name + ".minisig":
+			if minisignKey == "" {
+				fmt.Printf("  - minisign signature found; pass -minisign-key to verify it\n")
+				continue
+			}
+			sig := file + ".minisig"
+			if err := downloadFile(a.URL, sig); err != nil {
+				report(false, "minisign: %v", err)
+				continue
+			}
+			out, err := exec.Command("minisign", "-Vm", file, "-x", sig, "-P", minisignKey).CombinedOutput()
+			os.Remove(sig)
+			if err != nil {
+				report(false, "minisign: %s", strings.TrimSpace(string(out)))
+			} else {
+				report(true, "minisign signature is valid")
+			}
+			verified = true
+		case asset.Name + ".sigstore", asset.Name + ".sigstore.json", asset.Name + ".bundle":
+			fmt.Printf("  - sigstore bundle %s found; verify it with cosign verify-blob\n", a.Name)
+		}
+	}
+
+	if !verified {
+		report(false, "no checksum or signature published for %s", asset.Name)
+	}
+	return ok
+}
+
+// publishedChecksum finds the checksum of asset in a sidecar file
+// (<asset>.sha256) or a combined checksum file, returning it with the name
+// of the file it came from.
+func publishedChecksum(entry *ChangelogEntry, asset Asset) (string, string) {
+	for _, a := range entry.Assets {
+		sidecar := false
+		for _, suffix := range checksumSuffixes {
+			if a.Name == asset.Name+suffix {
+				sidecar = true
+			}
+		}
+		if !sidecar && (a.Name == asset.Name || !checksumFileRegex.MatchString(a.Name)) {
+			continue
+		}
+
+		body, err := fetchURL(a.URL)
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(string(body), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 0 {
+				continue
+			}
+			name := strings.TrimPrefix(fields[len(fields)-1], "*")
+			if (sidecar && len(fields) == 1) || name == asset.Name || filepath.Base(name) == asset.Name {
+				return strings.ToLower(fields[0]), a.Name
+			}
+		}
+	}
+	return "", ""
+}
+
+// fileChecksum hashes a file with SHA-256 or SHA-512, picked by the length
+// of the hex checksum it is compared with.
+func fileChecksum(file string, hexLen int) (string, error) {
+	var h hash.Hash
+	switch hexLen {
+	case 64:
+		h = sha256.New()
+	case 128:
+		h = sha512.New()
+	default:
+		return "", fmt.Errorf("unsupported checksum length %d", hexLen)
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
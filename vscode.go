@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const vscodeReleaseNoteCount = 12
+
+var vscodeNotesFileRegex = regexp.MustCompile(`^v1_(\d+)\.md$`)
+
+// fetchVSCodeReleaseNotes summarizes the monthly release notes kept in the
+// vscode-docs repo. The notes are long-form prose, so each "##" area becomes
+// a section and each "###" feature heading beneath it becomes a change. Only
+// the newest vscodeReleaseNoteCount releases are fetched.
+func fetchVSCodeReleaseNotes() ([]ChangelogEntry, error) {
+	const owner, repo, dir = "microsoft", "vscode-docs", "release-notes"
+
+	body, err := fetchURL(fmt.Sprintf("https://api.github.com/repos/%s/%s/contents/%s", owner, repo, dir))
+	if err != nil {
+		return nil, err
+	}
+
+	var contents []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(body, &contents); err != nil {
+		return nil, fmt.Errorf("failed to parse repository contents: %w", err)
+	}
+
+	var minors []int
+	for _, c := range contents {
+		if m := vscodeNotesFileRegex.FindStringSubmatch(c.Name); m != nil {
+			minor, _ := strconv.Atoi(m[1])
+			minors = append(minors, minor)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(minors)))
+	if len(minors) > vscodeReleaseNoteCount {
+		minors = minors[:vscodeReleaseNoteCount]
+	}
+
+	var entries []ChangelogEntry
+	for _, minor := range minors {
+		url := fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/HEAD/%s/v1_%d.md", owner, repo, dir, minor)
+		doc, err := fetchURL(url)
+		if err != nil {
+			return nil, err
+		}
+		entry := parseVSCodeReleaseNotes(string(doc))
+		entry.Version = fmt.Sprintf("1.%d", minor)
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func parseVSCodeReleaseNotes(doc string) ChangelogEntry {
+	var entry ChangelogEntry
+	var current *Section
+
+	for _, line := range strings.Split(doc, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case entry.ReleasedAt.IsZero() && strings.HasPrefix(trimmed, "Date:"):
+			entry.ReleasedAt, _ = time.Parse("2006-01-02", strings.TrimSpace(strings.TrimPrefix(trimmed, "Date:")))
+		case strings.HasPrefix(trimmed, "## "):
+			if current != nil && len(current.Changes) > 0 {
+				entry.Sections = append(entry.Sections, *current)
+			}
+			name := strings.TrimSpace(strings.TrimPrefix(trimmed, "## "))
+			current = &Section{Name: name, Category: sectionCategory(name)}
+		case strings.HasPrefix(trimmed, "### "):
+			change := strings.TrimSpace(strings.TrimPrefix(trimmed, "### "))
+			if current != nil {
+				current.Changes = append(current.Changes, change)
+			} else {
+				entry.Changes = append(entry.Changes, change)
+			}
+		}
+	}
+	if current != nil && len(current.Changes) > 0 {
+		entry.Sections = append(entry.Sections, *current)
+	}
+
+	return entry
+}
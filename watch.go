@@ -0,0 +1,80 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+// watchKeywords are the keywords of interest from the "watch" list in
+// sources.yaml. Changes mentioning one are starred in formats meant for
+// reading.
+var watchKeywords []string
+
+const watchMarker = "★ "
+
+// watchRegex matches any watched keyword, case-insensitively and as a whole
+// word where the keyword starts or ends with a word character. It is nil
+// when nothing is watched.
+func watchRegex() *regexp.Regexp {
+	if len(watchKeywords) == 0 {
+		return nil
+	}
+	alternatives := make([]string, len(watchKeywords))
+	for i, keyword := range watchKeywords {
+		alternatives[i] = keywordPattern(keyword)
+	}
+	return regexp.MustCompile("(?i)" + strings.Join(alternatives, "|"))
+}
+
+func keywordPattern(keyword string) string {
+	pattern := regexp.QuoteMeta(keyword)
+	isWord := func(b byte) bool {
+		return b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+	}
+	if isWord(keyword[0]) {
+		pattern = `\b` + pattern
+	}
+	if isWord(keyword[len(keyword)-1]) {
+		pattern += `\b`
+	}
+	return pattern
+}
+
+// markWatched lists the keywords each entry mentions in its Watched field
+// and, with star set, stars the changes that mention them. Formats that
+// process change text further (CSV, Keep a Changelog, templates) leave the
+// text alone.
+func markWatched(entries []ChangelogEntry, star bool) {
+	if len(watchKeywords) == 0 {
+		return
+	}
+	keywordRegexes := make([]*regexp.Regexp, len(watchKeywords))
+	for i, keyword := range watchKeywords {
+		keywordRegexes[i] = regexp.MustCompile("(?i)" + keywordPattern(keyword))
+	}
+
+	for i := range entries {
+		seen := make(map[string]bool)
+		mark := func(changes []string) {
+			for j, change := range changes {
+				matched := false
+				for k, re := range keywordRegexes {
+					if re.MatchString(change) {
+						matched = true
+						if keyword := watchKeywords[k]; !seen[keyword] {
+							seen[keyword] = true
+							entries[i].Watched = append(entries[i].Watched, keyword)
+						}
+					}
+				}
+				if matched && star {
+					changes[j] = watchMarker + change
+				}
+			}
+		}
+		for _, section := range entries[i].Sections {
+			mark(section.Changes)
+		}
+		mark(entries[i].Changes)
+	}
+}
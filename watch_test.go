@@ -0,0 +1,33 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMarkWatched(t *testing.T) {
+	defer func(keywords []string) { watchKeywords = keywords }(watchKeywords)
+	watchKeywords = []string{"MCP", "hooks"}
+
+	for _, star := range []bool{true, false} {
+		entries := []ChangelogEntry{{
+			Sections: []Section{{Name: "Added", Changes: []string{"Add MCP prompts", "Faster startup"}}},
+			Changes:  []string{"Fix pre-commit hooks", "Fix mcpserver name"},
+		}}
+		markWatched(entries, star)
+
+		prefix := ""
+		if star {
+			prefix = watchMarker
+		}
+		if got, want := entries[0].Sections[0].Changes, []string{prefix + "Add MCP prompts", "Faster startup"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("star=%v: section changes = %q, want %q", star, got, want)
+		}
+		if got, want := entries[0].Changes, []string{prefix + "Fix pre-commit hooks", "Fix mcpserver name"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("star=%v: changes = %q, want %q", star, got, want)
+		}
+		if got, want := entries[0].Watched, []string{"MCP", "hooks"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("star=%v: watched = %q, want %q", star, got, want)
+		}
+	}
+}
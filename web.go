@@ -0,0 +1,246 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	htmlDropRegex    = regexp.MustCompile(`(?is)<(script|style|svg|noscript|head)\b.*?</(script|style|svg|noscript|head)>`)
+	htmlCommentRegex = regexp.MustCompile(`(?s)<!--.*?-->`)
+	htmlHeadingRegex = regexp.MustCompile(`(?i)<h([1-6])\b[^>]*>`)
+	htmlItemRegex    = regexp.MustCompile(`(?i)<li\b[^>]*>`)
+	htmlBlockRegex   = regexp.MustCompile(`(?i)</?(p|div|br|ul|ol|li|h[1-6]|section|article|header|footer|tr|table)\b[^>]*>`)
+	htmlTagRegex     = regexp.MustCompile(`<[^>]*>`)
+)
+
+// httpClient is shared by every request so connections to the same host
+// (mostly api.github.com) are kept alive and reused across sources. Proxies
+// come from HTTP_PROXY, HTTPS_PROXY and NO_PROXY. Timeout is set by -timeout.
+var httpClient = &http.Client{
+	Timeout: 30 * time.Second,
+	Transport: &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConnsPerHost:   fetchWorkers,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: 30 * time.Second,
+	},
+}
+
+// httpStatusError reports a non-200 response.
+type httpStatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Status)
+}
+
+func fetchURL(url string) ([]byte, error) {
+	return fetchURLWithHeaders(url, nil)
+}
+
+// fetchURLUncached fetches url without reading or writing the cache, for
+// short-lived responses like registry tokens.
+func fetchURLUncached(url string, headers map[string]string) ([]byte, error) {
+	return fetchHTTP(url, headers, nil, false)
+}
+
+// fetchURLWithHeaders fetches url, reusing a cached response younger than
+// cacheTTL. Older cached responses are revalidated with a conditional
+// request, which GitHub doesn't count against the rate limit when it comes
+// back 304 Not Modified, and are used as-is if the rate limit is exhausted.
+func fetchURLWithHeaders(url string, headers map[string]string) ([]byte, error) {
+	return fetchHTTP(url, headers, nil, true)
+}
+
+// postURL POSTs payload to url for query APIs that take a request body,
+// caching the response by URL and payload like fetchURLWithHeaders.
+func postURL(url string, headers map[string]string, payload []byte) ([]byte, error) {
+	return fetchHTTP(url, headers, payload, true)
+}
+
+// fetchHTTP is fetchURLWithHeaders, POSTing payload when it isn't nil and
+// optionally bypassing the cache.
+func fetchHTTP(url string, headers map[string]string, payload []byte, useCache bool) ([]byte, error) {
+	cacheKey := url
+	if payload != nil {
+		cacheKey += "\n" + string(payload)
+	}
+	var cached *cachedResponse
+	var ok bool
+	if useCache {
+		cached, ok = readCache(cacheKey, headers)
+	}
+	if ok && cached.fresh() {
+		return cached.Body, nil
+	}
+
+	method, reqBody := "GET", io.Reader(nil)
+	if payload != nil {
+		method, reqBody = "POST", bytes.NewReader(payload)
+	}
+	req, err := http.NewRequest(method, url, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "aic-changelog")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if ok {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := doWithRetry(httpClient, req)
+	if err == nil && waitForRateLimit {
+		if limit := rateLimitFrom(resp); limit != nil {
+			resp.Body.Close()
+			limit.wait()
+			resp, err = doWithRetry(httpClient, req)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && ok {
+		cached.FetchedAt = time.Now()
+		writeCache(cacheKey, headers, cached)
+		return cached.Body, nil
+	}
+	if limit := rateLimitFrom(resp); limit != nil {
+		if ok {
+			warnStaleCache(limit, cached)
+			return cached.Body, nil
+		}
+		return nil, limit
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &httpStatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if useCache {
+		writeCache(cacheKey, headers, &cachedResponse{
+			URL:          url,
+			FetchedAt:    time.Now(),
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Body:         body,
+		})
+	}
+	return body, nil
+}
+
+func fetchHTMLChangelog(url, versionPattern string) ([]ChangelogEntry, error) {
+	body, err := fetchURL(url)
+	if err != nil {
+		return nil, err
+	}
+	return parseMarkdownChangelog(htmlToMarkdown(string(body)), versionPattern)
+}
+
+// htmlToMarkdown reduces an HTML page to markdown-ish lines: headings become
+// "#" headings, list items become "- " bullets and all other markup is
+// stripped. It is only meant to feed parseMarkdownChangelog.
+func htmlToMarkdown(s string) string {
+	s = htmlDropRegex.ReplaceAllString(s, "")
+	s = htmlCommentRegex.ReplaceAllString(s, "")
+	s = htmlHeadingRegex.ReplaceAllStringFunc(s, func(tag string) string {
+		level := htmlHeadingRegex.FindStringSubmatch(tag)[1]
+		return "\n" + strings.Repeat("#", int(level[0]-'0')) + " "
+	})
+	s = htmlItemRegex.ReplaceAllString(s, "\n- ")
+	s = htmlBlockRegex.ReplaceAllString(s, "\n")
+	s = htmlTagRegex.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+
+	var lines []string
+	bullet := false
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" {
+			continue
+		}
+		// <li><p>text</p></li> leaves the bullet on its own line
+		if line == "-" {
+			bullet = true
+			continue
+		}
+		if bullet && !strings.HasPrefix(line, "- ") {
+			line = "- " + line
+		}
+		bullet = false
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+var googleReleaseNoteTypes = map[string]bool{
+	"Feature":      true,
+	"Changed":      true,
+	"Fixed":        true,
+	"Issue":        true,
+	"Announcement": true,
+	"Deprecated":   true,
+	"Breaking":     true,
+	"Security":     true,
+	"Libraries":    true,
+}
+
+// fetchGoogleCloudReleaseNotes parses the release notes format used across
+// cloud.google.com: dated headings, each followed by type labels ("Feature",
+// "Fixed", ...) that introduce prose paragraphs rather than bullet lists.
+func fetchGoogleCloudReleaseNotes(url string) ([]ChangelogEntry, error) {
+	body, err := fetchURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	inNote := false
+	for _, line := range strings.Split(htmlToMarkdown(string(body)), "\n") {
+		switch {
+		case googleReleaseNoteTypes[line]:
+			lines = append(lines, "### "+line)
+			inNote = true
+		case strings.HasPrefix(line, "#"):
+			lines = append(lines, line)
+			inNote = false
+		case inNote && !strings.HasPrefix(line, "- "):
+			lines = append(lines, "- "+line)
+		default:
+			lines = append(lines, line)
+		}
+	}
+
+	return parseMarkdownChangelog(strings.Join(lines, "\n"), "")
+}
+
+func googleCloudReleaseNotes(url string) func() ([]ChangelogEntry, error) {
+	return func() ([]ChangelogEntry, error) { return fetchGoogleCloudReleaseNotes(url) }
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Slack rejects section text over 3000 characters and messages over 50
+// blocks.
+const (
+	slackTextLimit  = 3000
+	slackBlockLimit = 50
+)
+
+var slackBoldRegex = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+
+type slackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// outputSlack writes entries as a Slack Block Kit message that can be POSTed
+// as-is to an incoming webhook.
+func outputSlack(source Source, entries []ChangelogEntry) {
+	var msg slackMessage
+	for _, entry := range entries {
+		title := fmt.Sprintf("%s %s", source.DisplayName, entry.Version)
+		if msg.Text == "" {
+			msg.Text = title
+		}
+		blocks := []slackBlock{{Type: "header", Text: &slackText{Type: "plain_text", Text: title}}}
+
+		addSection := func(name string, changes []string) {
+			var lines []string
+			if name != "" {
+				lines = append(lines, "*"+slackEscape(name)+"*")
+			}
+			for _, change := range changes {
+				lines = append(lines, "• "+slackMarkdown(change))
+			}
+			for _, chunk := range chunkLines(lines, slackTextLimit) {
+				blocks = append(blocks, slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: chunk}})
+			}
+		}
+		for _, section := range entry.Sections {
+			addSection(section.Name, section.Changes)
+		}
+		if len(entry.Changes) > 0 {
+			addSection("", entry.Changes)
+		}
+
+		context := fmt.Sprintf("<%s|Full release notes>", entryURL(source, &entry))
+		if !entry.ReleasedAt.IsZero() {
+			context = entry.ReleasedAt.Format("2006-01-02") + " · " + context
+		}
+		blocks = append(blocks, slackBlock{Type: "context", Elements: []slackText{{Type: "mrkdwn", Text: context}}})
+
+		if len(msg.Blocks)+len(blocks) > slackBlockLimit {
+			fmt.Fprintf(os.Stderr, "Warning: Slack allows %d blocks per message, omitting entries from %s\n", slackBlockLimit, entry.Version)
+			break
+		}
+		msg.Blocks = append(msg.Blocks, blocks...)
+	}
+
+	writeJSON(msg)
+}
+
+// Discord rejects embed descriptions over 4096 characters and messages with
+// more than 10 embeds.
+const (
+	discordDescriptionLimit = 4096
+	discordEmbedLimit       = 10
+)
+
+type discordMessage struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title"`
+	URL         string         `json:"url,omitempty"`
+	Description string         `json:"description"`
+	Color       int            `json:"color"`
+	Timestamp   string         `json:"timestamp,omitempty"`
+	Footer      *discordFooter `json:"footer,omitempty"`
+}
+
+type discordFooter struct {
+	Text string `json:"text"`
+}
+
+// outputDiscord writes entries as a Discord webhook payload with an embed each.
+// Discord renders markdown itself, so changes are passed through unchanged.
+func outputDiscord(source Source, entries []ChangelogEntry) {
+	var msg discordMessage
+	for _, entry := range entries {
+		if len(msg.Embeds) == discordEmbedLimit {
+			fmt.Fprintf(os.Stderr, "Warning: Discord allows %d embeds per message, omitting entries from %s\n", discordEmbedLimit, entry.Version)
+			break
+		}
+
+		var lines []string
+		for _, section := range entry.Sections {
+			if len(lines) > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, "**"+section.Name+"**")
+			for _, change := range section.Changes {
+				lines = append(lines, "- "+change)
+			}
+		}
+		if len(entry.Sections) > 0 && len(entry.Changes) > 0 {
+			lines = append(lines, "")
+		}
+		for _, change := range entry.Changes {
+			lines = append(lines, "- "+change)
+		}
+
+		description := strings.Join(lines, "\n")
+		if len(description) > discordDescriptionLimit {
+			description = chunkLines(lines, discordDescriptionLimit-4)[0] + "\n..."
+		}
+
+		embed := discordEmbed{
+			Title:       fmt.Sprintf("%s %s", source.DisplayName, entry.Version),
+			URL:         entryURL(source, &entry),
+			Description: description,
+			Color:       0x5865F2,
+			Footer:      &discordFooter{Text: source.DisplayName},
+		}
+		if !entry.ReleasedAt.IsZero() {
+			embed.Timestamp = entry.ReleasedAt.Format(time.RFC3339)
+		}
+		msg.Embeds = append(msg.Embeds, embed)
+	}
+
+	writeJSON(msg)
+}
+
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     adaptiveCard `json:"content"`
+}
+
+type adaptiveCard struct {
+	Schema  string           `json:"$schema"`
+	Type    string           `json:"type"`
+	Version string           `json:"version"`
+	Body    []adaptiveText   `json:"body"`
+	Actions []adaptiveAction `json:"actions,omitempty"`
+}
+
+type adaptiveText struct {
+	Type     string `json:"type"`
+	Text     string `json:"text"`
+	Size     string `json:"size,omitempty"`
+	Weight   string `json:"weight,omitempty"`
+	IsSubtle bool   `json:"isSubtle,omitempty"`
+	Wrap     bool   `json:"wrap"`
+}
+
+type adaptiveAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// outputTeams writes entries as an Adaptive Card wrapped in the message envelope
+// Teams incoming webhooks expect.
+func outputTeams(source Source, entries []ChangelogEntry) {
+	card := adaptiveCard{
+		Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+		Type:    "AdaptiveCard",
+		Version: "1.4",
+		Actions: []adaptiveAction{{Type: "Action.OpenUrl", Title: "Full changelog", URL: source.URL()}},
+	}
+
+	// TextBlock markdown only recognizes lists separated by \r
+	list := func(changes []string) adaptiveText {
+		items := make([]string, len(changes))
+		for i, change := range changes {
+			items[i] = "- " + change
+		}
+		return adaptiveText{Type: "TextBlock", Text: strings.Join(items, "\r"), Wrap: true}
+	}
+
+	for _, entry := range entries {
+		card.Body = append(card.Body, adaptiveText{
+			Type:   "TextBlock",
+			Text:   fmt.Sprintf("%s %s", source.DisplayName, entry.Version),
+			Size:   "Large",
+			Weight: "Bolder",
+			Wrap:   true,
+		})
+		if !entry.ReleasedAt.IsZero() {
+			card.Body = append(card.Body, adaptiveText{Type: "TextBlock", Text: entry.ReleasedAt.Format("2006-01-02"), IsSubtle: true, Wrap: true})
+		}
+		for _, section := range entry.Sections {
+			card.Body = append(card.Body, adaptiveText{Type: "TextBlock", Text: section.Name, Weight: "Bolder", Wrap: true}, list(section.Changes))
+		}
+		if len(entry.Changes) > 0 {
+			card.Body = append(card.Body, list(entry.Changes))
+		}
+	}
+
+	writeJSON(teamsMessage{
+		Type:        "message",
+		Attachments: []teamsAttachment{{ContentType: "application/vnd.microsoft.card.adaptive", Content: card}},
+	})
+}
+
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// slackMarkdown converts a markdown change to Slack mrkdwn, which uses single
+// asterisks for bold and <url|text> for links.
+func slackMarkdown(s string) string {
+	var links []string
+	s = mdLinkRegex.ReplaceAllStringFunc(s, func(link string) string {
+		m := mdLinkRegex.FindStringSubmatch(link)
+		links = append(links, fmt.Sprintf("<%s|%s>", m[2], slackEscape(m[1])))
+		return fmt.Sprintf("\x00%d\x00", len(links)-1)
+	})
+	s = slackEscape(s)
+	s = slackBoldRegex.ReplaceAllString(s, "*$1*")
+	for i, link := range links {
+		s = strings.Replace(s, fmt.Sprintf("\x00%d\x00", i), link, 1)
+	}
+	return s
+}
+
+// chunkLines joins lines with newlines into chunks of at most limit bytes,
+// truncating any single line that wouldn't fit on its own.
+func chunkLines(lines []string, limit int) []string {
+	var chunks []string
+	var current string
+	for _, line := range lines {
+		if len(line) > limit {
+			line = line[:limit-3] + "..."
+		}
+		if current != "" && len(current)+1+len(line) > limit {
+			chunks = append(chunks, current)
+			current = ""
+		}
+		if current != "" {
+			current += "\n"
+		}
+		current += line
+	}
+	if current != "" {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+func writeJSON(v any) {
+	if err := encodeJSON(os.Stdout, v); err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding JSON: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func encodeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(v)
+}